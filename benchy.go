@@ -1,14 +1,20 @@
 package benchy
 
 import (
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/smarty/benchy/internal/benchmark"
 	"github.com/smarty/benchy/internal/params"
 	"github.com/smarty/benchy/internal/rendering"
+	"github.com/smarty/benchy/internal/terminal"
 	"github.com/smarty/benchy/options"
+	"github.com/smarty/benchy/providers"
 	"github.com/smarty/benchy/stats"
 )
 
@@ -16,11 +22,39 @@ import (
 type Benchy struct {
 	b               *testing.B
 	benchmarks      []*benchmark.Entry
-	printer         statPrinter
+	output          io.Writer
+	outputs         []output
+	theme           options.Theme
+	colorMode       options.ColorMode
+	width           int
+	histogramStyle  options.HistogramStyle
+	showPlot        bool
+	showProgress    bool
+	plotStyle       options.PlotStyle
 	printMemoryFunc rendering.ExtraRenderingFunc
+	printUsageFunc  rendering.ExtraRenderingFunc
 	profile         options.BenchmarkProfile
 	sampleCount     int
 	runningLong     bool
+
+	reportCard rendering.ReportCardSettings
+
+	metricsAddress string
+	observers      observers
+
+	profileDirectory   string
+	profileRetention   int
+	profileComparisons []profileComparison
+}
+
+type output struct {
+	writer io.Writer
+	format options.OutputFormat
+}
+
+type profileComparison struct {
+	baseName   string
+	targetName string
 }
 
 // New sets up a new Benchy, ready to benchmark some code.
@@ -34,7 +68,8 @@ type Benchy struct {
 func New(b *testing.B, profile options.BenchmarkProfile) *Benchy {
 	return &Benchy{
 		b:           b,
-		printer:     new(activePrinter),
+		output:      os.Stdout,
+		theme:       options.DefaultTheme(),
 		runningLong: !testing.Short(),
 		profile:     profile,
 	}
@@ -42,9 +77,76 @@ func New(b *testing.B, profile options.BenchmarkProfile) *Benchy {
 
 // DontPrintStats turns off printing stats. This is useful for automated systems
 // which are not going to examine the printout. Otherwise, you generally have
-// no reason ot turn off stats.
+// no reason ot turn off stats. Outputs added with [AddOutput] are still
+// written.
 func (this *Benchy) DontPrintStats() *Benchy {
-	this.printer = new(nullPrinter)
+	this.output = nil
+	return this
+}
+
+// SetOutput prints stats to `writer` instead of standard output.
+//
+// Parameters:
+//   - writer receives the human-readable histograms and report card.
+func (this *Benchy) SetOutput(writer io.Writer) *Benchy {
+	this.output = writer
+	return this
+}
+
+// LogOutput prints stats through the Log method of the *testing.B instead of
+// standard output, so they are attributed to the benchmark like any other
+// `go test` output.
+func (this *Benchy) LogOutput() *Benchy {
+	this.output = &logWriter{tb: this.b}
+	return this
+}
+
+// AddOutput writes the results to another output in addition to the printed
+// stats, for example a machine-readable file next to the terminal output.
+//
+// Parameters:
+//   - writer receives the output. It is not closed by Benchy.
+//   - format selects how the results are written. See
+//     [options.OutputFormat] for the available formats.
+func (this *Benchy) AddOutput(writer io.Writer, format options.OutputFormat) *Benchy {
+	this.outputs = append(this.outputs, output{writer: writer, format: format})
+	return this
+}
+
+// SetTheme sets the colors used to print stats. Default is
+// [options.DefaultTheme].
+//
+// Parameters:
+//   - theme defines the colors. See [options.Theme] for the parts of the stats
+//     that can be colored, and [options.HighContrastTheme] for an alternative.
+func (this *Benchy) SetTheme(theme options.Theme) *Benchy {
+	this.theme = theme
+	return this
+}
+
+// SetColorMode sets when stats are printed in color. Default is
+// [options.ColorAuto], which only prints in color to a terminal.
+//
+// Parameters:
+//   - mode is when to use color. If the flag `-test.benchy.color` is set from
+//     the CLI, then that flag definition will take precedence over this
+//     method. Otherwise, a non-empty `NO_COLOR` environment variable turns
+//     color off.
+func (this *Benchy) SetColorMode(mode options.ColorMode) *Benchy {
+	this.colorMode = mode
+	return this
+}
+
+// SetWidth sets the number of columns that plots and histograms are fitted to.
+// Default is 0, which fits them to the terminal, or to 100 columns when the
+// output is not a terminal.
+//
+// Parameters:
+//   - width is the number of columns. If the flag `-test.benchy.width` is set
+//     from the CLI, then that flag definition will take precedence over this
+//     method.
+func (this *Benchy) SetWidth(width int) *Benchy {
+	this.width = width
 	return this
 }
 
@@ -60,6 +162,32 @@ func (this *Benchy) SetSampleCount(sampleCount int) *Benchy {
 	return this
 }
 
+// SetProfileDirectory sets the directory that profiles are written to. Every
+// run creates a new timestamped directory inside it, with one directory per
+// benchmark. Default is "./workspace".
+//
+// Parameters:
+//   - directory is the root directory for profiles. If the flag
+//     `-test.benchy.profiledir` is set from the CLI, then that flag definition
+//     will take precedence over this method.
+func (this *Benchy) SetProfileDirectory(directory string) *Benchy {
+	this.profileDirectory = directory
+	return this
+}
+
+// SetProfileRetention limits how many runs are kept in the profile directory
+// (See [SetProfileDirectory]). After profiling, the oldest runs are deleted.
+// Default is 0, which keeps all runs.
+//
+// Parameters:
+//   - runs is the number of runs to keep, including the current one. If the
+//     flag `-test.benchy.keepruns` is set from the CLI, then that flag
+//     definition will take precedence over this method.
+func (this *Benchy) SetProfileRetention(runs int) *Benchy {
+	this.profileRetention = runs
+	return this
+}
+
 // ShowMemoryStats activates the rendering of memory statistics.
 //
 // Benchy must have a sample count of at least stats.MinFullCalculation to show
@@ -69,6 +197,79 @@ func (this *Benchy) ShowMemoryStats() *Benchy {
 	return this
 }
 
+// SetHistogramStyle sets how the histogram of every benchmark is drawn. The
+// bars are always scaled to fit the width (See [SetWidth]). Default is
+// horizontal bars for buckets of equal width.
+//
+// Parameters:
+//   - style selects log-scaled buckets and vertical bars. See
+//     [options.HistogramStyle] for details.
+func (this *Benchy) SetHistogramStyle(style options.HistogramStyle) *Benchy {
+	this.histogramStyle = style
+	return this
+}
+
+// ShowPlot activates the rendering of the samples of all benchmarks on a shared
+// time axis, which makes it easy to compare their distributions. The plot is
+// printed after the histograms.
+//
+// Benchy must have a sample count of at least stats.MinFullCalculation to show
+// the plot.
+//
+// Parameters:
+//   - style is how the distributions are drawn. See [options.PlotStyle] for
+//     the available styles.
+func (this *Benchy) ShowPlot(style options.PlotStyle) *Benchy {
+	this.showPlot = true
+	this.plotStyle = style
+	return this
+}
+
+// ShowProgress turns on showing the progress of every benchmark while it is
+// sampled, with the current sample, the running median and the estimated time
+// left. The progress is written to standard error, on a single line that is
+// updated when it is a terminal, or as a log line every 10 seconds otherwise.
+//
+// `-test.benchy.progress` in the CLI flags takes precedence.
+func (this *Benchy) ShowProgress() *Benchy {
+	this.showProgress = true
+	return this
+}
+
+// ShowResourceUsage activates the rendering of resource usage statistics: user
+// and system CPU time, voluntary and involuntary context switches, and minor
+// and major page faults, all per operation. These tell CPU-bound benchmarks
+// apart from those waiting on I/O or the scheduler.
+//
+// Resource usage is measured for the whole process and is only recorded on
+// Linux. Benchy must have a sample count of at least stats.MinFullCalculation
+// to show any statistics.
+func (this *Benchy) ShowResourceUsage() *Benchy {
+	this.printUsageFunc = rendering.RenderResourceUsageFunc
+	return this
+}
+
+// ServeMetrics serves the results of every benchmark that has finished on a
+// Prometheus /metrics endpoint at `address`, such as "localhost:9090", while
+// the run continues. This allows long-running benchmarks to be scraped. The
+// endpoint is closed when the benchmark function returns.
+//
+// `-test.benchy.metrics address` in the CLI flags takes precedence.
+func (this *Benchy) ServeMetrics(address string) *Benchy {
+	this.metricsAddress = address
+	return this
+}
+
+// AddObserver registers an observer which receives the events of the run:
+// before and after every benchmark, after every sample (including those that
+// measure the overhead), after the overhead is measured, and at the end of the
+// run. See [Observer] for details, and [ObserverFunc] to observe with a
+// function.
+func (this *Benchy) AddObserver(observer Observer) *Benchy {
+	this.observers = append(this.observers, observer)
+	return this
+}
+
 // RegisterBenchmark adds a new function to be benchmarked.
 //
 // Parameters:
@@ -131,6 +332,34 @@ func (this *Benchy) RegisterBenchmark(name string, benchmarkFunction func(), fla
 	return this
 }
 
+// RegisterBenchmarkWithCtx adds a new function to be benchmarked, which
+// receives a [Ctx] to report custom metrics with. Custom metrics get full
+// statistics in the results (See [stats.Metric]) and their own columns in the
+// report card.
+//
+// Parameters:
+//   - name is a unique identifier for the registered function. If the name is
+//     not unique among registered functions, then the benchmark will fail.
+//   - benchmarkFunction is called once per operation with the same [Ctx].
+//   - flags sets any number of options for this benchmark function. See
+//     [options.BenchmarkFlag] for details on what options are available and
+//     better descriptions on what they do.
+//
+// Example:
+//
+//	benchy.New(b, options.Medium).
+//	RegisterBenchmarkWithCtx("cache", func(ctx *benchy.Ctx) {
+//		hit := cache.Get(key)
+//		ctx.ReportMetric("hit ratio", boolToFloat(hit), "ratio")
+//	}).
+//	Run()
+func (this *Benchy) RegisterBenchmarkWithCtx(name string, benchmarkFunction func(*Ctx), flags ...options.BenchmarkFlag) *Benchy {
+	ctx := &Ctx{metrics: benchmark.NewMetrics()}
+	this.RegisterBenchmark(name, func() { benchmarkFunction(ctx) }, flags...)
+	this.benchmarks[len(this.benchmarks)-1].Metrics = ctx.metrics
+	return this
+}
+
 // RegisterSetup adds a setup function to the already named and registered
 // benchmark. Setup functions will run on Benchy sample (See [SetSampleCount]).
 // When a setup is registered for a function, it will automatically turn on
@@ -197,6 +426,180 @@ func (this *Benchy) RegisterCleanup(benchmarkName string, cleanupFunction func()
 	return this
 }
 
+// RegisterPrefetch adds a prefetcher, such as a generator provider with
+// [providers.GeneratorProvider1.WithPrefetch], to the already named and
+// registered benchmark. The prefetcher generates values before every sample,
+// and again whenever they run out during a sample, while the timer is stopped
+// and allocations and resource usage are not counted.
+//
+// Parameters:
+//   - benchmarkName must be the identifier for a benchmark function that has
+//     already been registered.
+//   - prefetcher generates the values that the benchmark function uses.
+func (this *Benchy) RegisterPrefetch(benchmarkName string, prefetcher providers.Prefetcher) *Benchy {
+	registered := false
+	for _, entry := range this.benchmarks {
+		if !strings.EqualFold(entry.Name, benchmarkName) {
+			continue
+		}
+
+		entry.Prefetch = prefetcher.Prefetch
+		registered = true
+		break
+	}
+
+	if !registered {
+		this.b.Errorf(
+			"registering a prefetcher for '%s' failed, this benchmark has not yet been registered",
+			benchmarkName)
+	}
+
+	return this
+}
+
+// CompareProfiles compares the CPU profiles of two registered benchmarks once
+// they have run. A copy of the base profile and a bash script which opens both
+// with `go tool pprof -diff_base` are written next to the target's profile,
+// and the functions whose share of CPU time changed the most are printed
+// after the report card.
+//
+// Parameters:
+//   - baseName must be the identifier for a benchmark function that has
+//     already been registered with [options.PProfCPU].
+//   - targetName must be the identifier for a benchmark function that has
+//     already been registered with [options.PProfCPU].
+func (this *Benchy) CompareProfiles(baseName string, targetName string) *Benchy {
+	for _, name := range []string{baseName, targetName} {
+		entry := this.findEntry(name)
+		if entry == nil {
+			this.b.Errorf(
+				"comparing profiles of '%s' failed, this benchmark has not yet been registered",
+				name)
+			return this
+		}
+
+		if !entry.Flags.Contains(options.PProfCPU) {
+			this.b.Errorf(
+				"comparing profiles of '%s' failed, this benchmark was not registered with options.PProfCPU",
+				name)
+			return this
+		}
+	}
+
+	this.profileComparisons = append(this.profileComparisons, profileComparison{
+		baseName:   baseName,
+		targetName: targetName,
+	})
+
+	return this
+}
+
+// AddColumn adds a custom column to the report card, after all other columns.
+// Values are aligned and, when they are durations or throughputs, rendered in
+// the smallest fitting unit of the column.
+//
+// Parameters:
+//   - title is the header of the column.
+//   - value gets the value to show for each benchmark result, for example a
+//     custom metric (See [stats.BenchmarkResult.FindMetric]).
+//   - formatter describes how the values are rendered and when the column is
+//     shown. See [options.ColumnFormat] for the defaults.
+func (this *Benchy) AddColumn(title string, value func(result *stats.BenchmarkResult) any, formatter options.ColumnFormat) *Benchy {
+	this.reportCard.Columns = append(this.reportCard.Columns, rendering.CustomColumn{
+		Title:  title,
+		Value:  value,
+		Format: formatter,
+	})
+
+	return this
+}
+
+// SetBaseline compares every benchmark in the report card to an already
+// registered benchmark. A "RELATIVE" column shows how many times slower or
+// faster each benchmark is on average (for example "2.35x slower"), and every
+// duration is followed by its change from the baseline in percent.
+//
+// Parameters:
+//   - name is the name of the benchmark that is the baseline.
+func (this *Benchy) SetBaseline(name string) *Benchy {
+	if this.findEntry(name) == nil {
+		this.b.Errorf("setting the baseline to '%s' failed, this benchmark has not yet been registered", name)
+		return this
+	}
+
+	this.reportCard.Baseline = name
+	this.reportCard.FastestBaseline = false
+	return this
+}
+
+// UseFastestBaseline compares every benchmark in the report card to the
+// benchmark with the lowest average, like [SetBaseline].
+func (this *Benchy) UseFastestBaseline() *Benchy {
+	this.reportCard.Baseline = ""
+	this.reportCard.FastestBaseline = true
+	return this
+}
+
+// RankBy sorts the benchmarks in the report card by a statistic, fastest
+// first. Otherwise, benchmarks are shown in the order they were registered.
+//
+// Parameters:
+//   - statistic is the duration to sort by. See [options.Statistic] for the
+//     available statistics.
+func (this *Benchy) RankBy(statistic options.Statistic) *Benchy {
+	this.reportCard.Ranked = true
+	this.reportCard.RankBy = statistic
+	return this
+}
+
+// SetBytesPerOp sets the number of bytes that each operation of an already
+// registered benchmark processes, like [testing.B.SetBytes]. The results then
+// include the throughput in bytes per second (See
+// [stats.BenchmarkResult.ByteThroughput]), which is also shown in the report
+// card.
+//
+// Parameters:
+//   - benchmarkName must be the identifier for a benchmark function that has
+//     already been registered.
+//   - bytes is the number of bytes processed by a single call of the
+//     benchmark function.
+func (this *Benchy) SetBytesPerOp(benchmarkName string, bytes int64) *Benchy {
+	entry := this.findEntry(benchmarkName)
+	if entry == nil {
+		this.b.Errorf(
+			"setting bytes per operation for '%s' failed, this benchmark has not yet been registered",
+			benchmarkName)
+		return this
+	}
+
+	entry.BytesPerOp = bytes
+	return this
+}
+
+// SetItemsPerOp sets the number of items, such as records or requests, that
+// each operation of an already registered benchmark processes. The results
+// then include the throughput in items per second (See
+// [stats.BenchmarkResult.ItemThroughput]), which is also shown in the report
+// card.
+//
+// Parameters:
+//   - benchmarkName must be the identifier for a benchmark function that has
+//     already been registered.
+//   - items is the number of items processed by a single call of the
+//     benchmark function.
+func (this *Benchy) SetItemsPerOp(benchmarkName string, items int64) *Benchy {
+	entry := this.findEntry(benchmarkName)
+	if entry == nil {
+		this.b.Errorf(
+			"setting items per operation for '%s' failed, this benchmark has not yet been registered",
+			benchmarkName)
+		return this
+	}
+
+	entry.ItemsPerOp = items
+	return this
+}
+
 // Run runs all the registered benchmarks and returns the results.
 //
 // Returns:
@@ -204,30 +607,163 @@ func (this *Benchy) RegisterCleanup(benchmarkName string, cleanupFunction func()
 //     benchmarks that have run. See [stats.BenchmarkResults] for more details.
 func (this *Benchy) Run() (benchmarkResults *stats.BenchmarkResults) {
 	this.sampleCount = params.SelectSampleCount(this.sampleCount, this.profile, os.Args)
+	this.profileDirectory = params.SelectProfileDirectory(this.profileDirectory, os.Args)
+	this.profileRetention = params.SelectProfileRetention(this.profileRetention, os.Args)
+
+	metrics := this.startMetricsServer()
+	observer := slices.Clone(this.observers)
+	if params.SelectProgress(this.showProgress, os.Args) {
+		observer = append(observer, newProgressPrinter(os.Stderr))
+	}
+
+	profiled := false
+	runDirectory := benchmark.RunDirectory(this.profileDirectory, time.Now())
 	for _, entry := range this.benchmarks {
 		if entry.Flags.Contains(options.Long) && !this.runningLong {
 			continue
 		}
 
+		entry.ProfileDirectory = filepath.Join(runDirectory, benchmark.SanitizeName(entry.Name))
+		entry.Progress = nil
+		if len(observer) > 0 {
+			entry.Progress = func(progress benchmark.Progress) {
+				observer.Observe(newSampleTaken(progress))
+			}
+		}
+
+		profiled = profiled || entry.Flags.Contains(options.PProfCPU) || entry.Flags.Contains(options.Trace)
+		observer.Observe(BenchmarkStarted{Name: entry.Name, SampleCount: this.sampleCount})
 		benchmark.SampleOverhead(this.b, entry, this.sampleCount)
+		if entry.Flags.Contains(options.OverheadSampling) {
+			observer.Observe(OverheadMeasured{Name: entry.Name, Overhead: entry.Overhead})
+		}
+
 		benchmark.Sample(this.b, entry, this.sampleCount)
+		observer.Observe(BenchmarkFinished{Name: entry.Name, Result: entry.Results})
+		if metrics != nil {
+			metrics.publish(entry.Results)
+		}
+	}
+
+	if profiled {
+		if err := benchmark.PruneRuns(this.profileDirectory, this.profileRetention); err != nil {
+			this.b.Errorf("cannot remove old profile runs: %v", err)
+		}
 	}
 
+	printer := this.createPrinter()
 	results := make([]*stats.BenchmarkResult, 0, len(this.benchmarks))
 	for _, entry := range this.benchmarks {
 		if entry.Flags.Contains(options.Long) && !this.runningLong {
 			continue
 		}
 
-		this.printer.printHistogram(entry.Results, this.sampleCount)
+		printer.printHistogram(entry.Results, this.sampleCount, this.histogramStyle)
+		printer.printHotFunctions(entry.Results)
 		results = append(results, entry.Results)
 	}
 
+	if len(results) > 0 && this.showPlot {
+		printer.printDistributionPlot(results, this.sampleCount, this.plotStyle)
+	}
+
 	if len(results) > 0 {
-		this.printer.printReportCard(results, this.sampleCount, this.printMemoryFunc)
+		printer.printReportCard(
+			results,
+			this.sampleCount,
+			this.reportCard,
+			extraColumns(this.printMemoryFunc, this.printUsageFunc))
+	}
+
+	for _, comparison := range this.profileComparisons {
+		base := this.findEntry(comparison.baseName)
+		target := this.findEntry(comparison.targetName)
+		if base == nil || target == nil {
+			this.b.Errorf(
+				"comparing profiles of '%s' and '%s' failed, both benchmarks must be registered",
+				comparison.baseName,
+				comparison.targetName)
+			continue
+		}
+
+		deltas := benchmark.CompareProfiles(this.b, base, target)
+		printer.printProfileDiff(base.Name, target.Name, deltas)
 	}
 
 	benchmarkResults = stats.NewBenchmarkResults(this.b)
 	benchmarkResults.Collection = results
+	observer.Observe(RunFinished{Results: benchmarkResults})
 	return benchmarkResults
 }
+
+// startMetricsServer starts serving metrics when an address is set, until the
+// benchmark function has finished.
+func (this *Benchy) startMetricsServer() *metricsServer {
+	address := params.SelectMetricsAddress(this.metricsAddress, os.Args)
+	if address == "" {
+		return nil
+	}
+
+	metrics, err := startMetricsServer(address)
+	if err != nil {
+		this.b.Errorf("cannot serve metrics: %v", err)
+		return nil
+	}
+
+	this.b.Cleanup(metrics.close)
+	return metrics
+}
+
+func (this *Benchy) createPrinter() multiPrinter {
+	colorMode := params.SelectColorMode(this.colorMode, os.Args, os.Getenv("NO_COLOR"))
+	width := params.SelectWidth(this.width, os.Args)
+	printers := make(multiPrinter, 0, len(this.outputs)+1)
+	if this.output != nil {
+		printers = append(printers, newActivePrinter(this.output, this.selectTheme(colorMode, this.output), selectWidth(width, this.output)))
+	}
+
+	for _, output := range this.outputs {
+		printers = append(printers, newPrinter(this.b, output.writer, output.format, this.selectTheme(colorMode, output.writer), selectWidth(width, output.writer)))
+	}
+
+	return printers
+}
+
+func (this *Benchy) selectTheme(colorMode options.ColorMode, writer io.Writer) options.Theme {
+	switch colorMode {
+	case options.ColorAlways:
+		return this.theme
+
+	case options.ColorNever:
+		return options.NoColorTheme()
+
+	default:
+		if terminal.IsTerminal(writer) {
+			return this.theme
+		}
+
+		return options.NoColorTheme()
+	}
+}
+
+func selectWidth(width int, writer io.Writer) int {
+	if width > 0 {
+		return width
+	}
+
+	if terminalWidth := terminal.Width(writer); terminalWidth > 0 {
+		return terminalWidth
+	}
+
+	return rendering.DefaultWidth
+}
+
+func (this *Benchy) findEntry(name string) *benchmark.Entry {
+	for _, entry := range this.benchmarks {
+		if strings.EqualFold(entry.Name, name) {
+			return entry
+		}
+	}
+
+	return nil
+}
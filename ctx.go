@@ -0,0 +1,30 @@
+package benchy
+
+import (
+	"github.com/smarty/benchy/internal/benchmark"
+)
+
+// Ctx is passed to benchmark functions registered with
+// [Benchy.RegisterBenchmarkWithCtx], and lets them report custom metrics.
+type Ctx struct {
+	metrics *benchmark.Metrics
+}
+
+// ReportMetric adds `value` to the metric called `name`. All values reported
+// during a sample are summed and divided by the number of operations, so every
+// metric is an average per operation. For example, reporting 1 on a cache hit
+// and 0 on a miss gives a hit ratio, and reporting the number of bytes written
+// gives the bytes per operation.
+//
+// Reporting a metric happens while the benchmark is timed, so it adds a small
+// amount of overhead to every operation.
+//
+// Parameters:
+//   - name identifies the metric. Metrics appear in the report card in the
+//     order they were first reported.
+//   - value is the amount to add for this operation.
+//   - unit describes the metric, such as "bytes" or "ratio". Only the unit of
+//     the first report is kept.
+func (this *Ctx) ReportMetric(name string, value float64, unit string) {
+	this.metrics.Record(name, value, unit)
+}
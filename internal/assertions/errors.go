@@ -9,6 +9,9 @@ import (
 var (
 	AssertionFailedError     = fmt.Errorf("assertion failed")
 	NotEnoughBenchmarksError = fmt.Errorf("not enough benchmarks")
+	MissingProfileError      = fmt.Errorf("missing profile")
+	MissingMetricError       = fmt.Errorf("missing metric")
+	MissingThroughputError   = fmt.Errorf("missing throughput")
 )
 
 func generateNoRightHandError(leftName string) error {
@@ -19,8 +22,12 @@ func generateNoRightHandError(leftName string) error {
 }
 
 func generateError(format string, innerError error, data ...any) error {
-	functionName := getCallingFunctionName()
+	return generateNamedError(getCallingFunctionName(), format, innerError, data...)
+}
 
+// generateNamedError is used by assertions built from closures, where the name
+// of the calling function is not the name of the assertion.
+func generateNamedError(functionName string, format string, innerError error, data ...any) error {
 	message := fmt.Sprintf(format, data...)
 	return fmt.Errorf("%w: \"%s\" %s", innerError, functionName, message)
 }
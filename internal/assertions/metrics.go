@@ -0,0 +1,66 @@
+package assertions
+
+import (
+	. "github.com/smarty/benchy/stats"
+)
+
+// IsMetricAtLeast builds an assertion that the average of the custom metric
+// `name` is at least `minimum`.
+func IsMetricAtLeast(name string, minimum float64) TestOperator {
+	return func(left *BenchmarkResult, right ...*BenchmarkResult) error {
+		metric, found := left.FindMetric(name)
+		if !found {
+			return generateMissingMetricError("IsMetricAtLeast", left.Name, name)
+		}
+
+		if metric.Average < minimum {
+			return generateNamedError(
+				"IsMetricAtLeast",
+				"expected \"%s\" of \"%s\" to be at least %g %s, but it was %g %s",
+				AssertionFailedError,
+				name,
+				left.Name,
+				minimum,
+				metric.Unit,
+				metric.Average,
+				metric.Unit)
+		}
+
+		return nil
+	}
+}
+
+// IsMetricAtMost builds an assertion that the average of the custom metric
+// `name` is at most `maximum`.
+func IsMetricAtMost(name string, maximum float64) TestOperator {
+	return func(left *BenchmarkResult, right ...*BenchmarkResult) error {
+		metric, found := left.FindMetric(name)
+		if !found {
+			return generateMissingMetricError("IsMetricAtMost", left.Name, name)
+		}
+
+		if metric.Average > maximum {
+			return generateNamedError(
+				"IsMetricAtMost",
+				"expected \"%s\" of \"%s\" to be at most %g %s, but it was %g %s",
+				AssertionFailedError,
+				name,
+				left.Name,
+				maximum,
+				metric.Unit,
+				metric.Average,
+				metric.Unit)
+		}
+
+		return nil
+	}
+}
+
+func generateMissingMetricError(functionName string, benchmarkName string, metricName string) error {
+	return generateNamedError(
+		functionName,
+		"expected \"%s\" to report the metric \"%s\", but it did not",
+		MissingMetricError,
+		benchmarkName,
+		metricName)
+}
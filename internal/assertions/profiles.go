@@ -0,0 +1,36 @@
+package assertions
+
+import (
+	. "github.com/smarty/benchy/stats"
+)
+
+// IsNotHot builds an assertion that `functionName` is not among the `top`
+// functions using the most CPU time. The benchmark must be registered with
+// options.PProfCPU. A profile without any CPU time has no hot functions, so
+// the assertion holds.
+func IsNotHot(functionName string, top int) TestOperator {
+	return func(left *BenchmarkResult, right ...*BenchmarkResult) error {
+		if left.HotFunctions == nil {
+			return generateNamedError(
+				"IsNotHot",
+				"expected a cpu profile for \"%s\", but none was recorded",
+				MissingProfileError,
+				left.Name)
+		}
+
+		rank, function := left.FindHotFunction(functionName)
+		if rank >= 0 && rank < top {
+			return generateNamedError(
+				"IsNotHot",
+				"expected \"%s\" not to be in the top %d functions of \"%s\", but it was number %d with %0.2f%%",
+				AssertionFailedError,
+				functionName,
+				top,
+				left.Name,
+				rank+1,
+				function.FlatPercent)
+		}
+
+		return nil
+	}
+}
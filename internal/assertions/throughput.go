@@ -0,0 +1,40 @@
+package assertions
+
+import (
+	. "github.com/smarty/benchy/stats"
+)
+
+// IsThroughputAtLeast builds an assertion that the average throughput is at
+// least `perSecond`. Byte throughput is used when bytes per operation are set,
+// otherwise item throughput is used.
+func IsThroughputAtLeast(perSecond float64) TestOperator {
+	return func(left *BenchmarkResult, right ...*BenchmarkResult) error {
+		throughput := left.ByteThroughput.Average
+		suffix := BytesSuffix
+		switch {
+		case left.BytesPerOp > 0:
+		case left.ItemsPerOp > 0:
+			throughput = left.ItemThroughput.Average
+			suffix = ItemsSuffix
+		default:
+			return generateNamedError(
+				"IsThroughputAtLeast",
+				"expected \"%s\" to have bytes or items per operation set, but it did not",
+				MissingThroughputError,
+				left.Name)
+		}
+
+		if float64(throughput) < perSecond {
+			expected := Throughput(perSecond)
+			return generateNamedError(
+				"IsThroughputAtLeast",
+				"expected \"%s\" to process at least %s, but it processed %s",
+				AssertionFailedError,
+				left.Name,
+				expected.Render(suffix),
+				throughput.Render(suffix))
+		}
+
+		return nil
+	}
+}
@@ -1,6 +1,7 @@
 package benchmark
 
 import (
+	"github.com/smarty/benchy/internal/profiling"
 	"github.com/smarty/benchy/options"
 	"github.com/smarty/benchy/stats"
 )
@@ -13,6 +14,11 @@ type Entry struct {
 	// Setup is run before the benchmark.
 	Setup func()
 
+	// Prefetch is run before the benchmark, when it is not `nil`, with a
+	// function that runs work while the sample is not measured. Prefetch can
+	// keep that function to use it again while the benchmark runs.
+	Prefetch func(untimed func(function func()))
+
 	// BenchmarkFunction is the actual function that is benchmarked.
 	BenchmarkFunction func()
 
@@ -25,6 +31,27 @@ type Entry struct {
 	// Results is the actual results of the benchmark.
 	Results *stats.BenchmarkResult
 
+	// BytesPerOp is the number of bytes processed by each operation.
+	BytesPerOp int64
+
+	// ItemsPerOp is the number of items processed by each operation.
+	ItemsPerOp int64
+
+	// Metrics collects custom metrics reported by the benchmark function. It is
+	// `nil` when the benchmark function cannot report metrics.
+	Metrics *Metrics
+
+	// Progress is called after every sample is taken, when it is not `nil`.
+	Progress func(Progress)
+
 	// Flags describes options on this entry.
 	Flags options.BenchmarkFlag
+
+	// CPUProfile is the merged CPU profile of all samples. It is only set when
+	// the entry is flagged with options.PProfCPU.
+	CPUProfile *profiling.Profile
+
+	// ProfileDirectory is where profiles of this entry are written. It is set
+	// for every run, inside the run directory.
+	ProfileDirectory string
 }
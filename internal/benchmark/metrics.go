@@ -0,0 +1,72 @@
+package benchmark
+
+import (
+	"github.com/smarty/benchy/stats"
+)
+
+// Metrics collects custom metrics reported by a benchmark function. Values are
+// summed over a batch and divided by the number of operations, so that every
+// sample holds the average value per operation.
+type Metrics struct {
+	totals  map[string]*metricTotal
+	metrics []stats.Metric
+}
+
+type metricTotal struct {
+	index int
+	sum   float64
+}
+
+// NewMetrics creates an empty collection of custom metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		totals: make(map[string]*metricTotal),
+	}
+}
+
+// Record adds `value` to the metric `name` for the current batch. The first
+// report of a metric also sets its unit.
+func (this *Metrics) Record(name string, value float64, unit string) {
+	total, found := this.totals[name]
+	if !found {
+		total = &metricTotal{index: len(this.metrics)}
+		this.totals[name] = total
+		this.metrics = append(this.metrics, stats.Metric{Name: name, Unit: unit})
+	}
+
+	total.sum += value
+}
+
+// Reset forgets the values of the current batch, ahead of a new batch.
+func (this *Metrics) Reset() {
+	for _, total := range this.totals {
+		total.sum = 0
+	}
+}
+
+// Clear forgets everything, ahead of a new set of samples.
+func (this *Metrics) Clear() {
+	clear(this.totals)
+	this.metrics = nil
+}
+
+// Commit records the values of the current batch as a sample.
+//
+// Parameters:
+//   - n is the number of cycles in the last benchmark run.
+func (this *Metrics) Commit(n int) {
+	for _, total := range this.totals {
+		metric := &this.metrics[total.index]
+		metric.Samples = append(metric.Samples, total.sum/float64(max(1, n)))
+	}
+}
+
+// WriteTo writes the samples of every metric to the `result`.
+func (this *Metrics) WriteTo(result *stats.BenchmarkResult) {
+	if len(this.metrics) == 0 {
+		return
+	}
+
+	result.Metrics = make([]stats.Metric, len(this.metrics))
+	copy(result.Metrics, this.metrics)
+}
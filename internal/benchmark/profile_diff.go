@@ -0,0 +1,63 @@
+package benchmark
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/smarty/benchy/internal/benchmark/strategies"
+	"github.com/smarty/benchy/internal/profiling"
+)
+
+// CompareProfiles writes the merged CPU profile of `base` into the profile
+// directory of `target`, along with a bash script which opens the two with
+// `go tool pprof -diff_base`.
+//
+// Parameters:
+//   - b is the benchmark runner.
+//   - base is the entry to compare against.
+//   - target is the entry being compared.
+//
+// Returns:
+//   - deltas are the functions whose share of CPU time changed, largest change
+//     first. It is `nil` when either entry has no CPU profile, which fails `b`.
+func CompareProfiles(b testing.TB, base *Entry, target *Entry) (deltas []profiling.FunctionDelta) {
+	for _, entry := range []*Entry{base, target} {
+		if entry.CPUProfile == nil {
+			b.Errorf(
+				"comparing the profiles of '%s' and '%s' failed, '%s' has no cpu profile because it did not run or its profile could not be recorded",
+				base.Name,
+				target.Name,
+				entry.Name)
+			return nil
+		}
+	}
+
+	buffer := &bytes.Buffer{}
+	if _, err := base.CPUProfile.WriteTo(buffer); err != nil {
+		b.Error(err)
+		return nil
+	}
+
+	baseFilename := fmt.Sprintf("diff_base_%s.pprof", SanitizeName(base.Name))
+	err := os.WriteFile(filepath.Join(target.ProfileDirectory, baseFilename), buffer.Bytes(), strategies.FilePermissions)
+	if err != nil {
+		b.Error(err)
+		return nil
+	}
+
+	script := fmt.Sprintf(
+		"#!/bin/bash\ngo tool pprof -http localhost:8080 -diff_base '%s' %s",
+		baseFilename,
+		strategies.MergedProfileFilename)
+
+	scriptFilename := fmt.Sprintf("pprof_diff_%s.sh", SanitizeName(base.Name))
+	err = os.WriteFile(filepath.Join(target.ProfileDirectory, scriptFilename), []byte(script), strategies.ScriptPermissions)
+	if err != nil {
+		b.Error(err)
+	}
+
+	return profiling.Diff(base.CPUProfile, target.CPUProfile, "cpu")
+}
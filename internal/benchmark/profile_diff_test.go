@@ -0,0 +1,35 @@
+package benchmark
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/smarty/benchy/internal/profiling"
+)
+
+// errorRecorder records the messages of Errorf, without failing the test.
+type errorRecorder struct {
+	testing.TB
+	errors []string
+}
+
+func (this *errorRecorder) Errorf(format string, args ...any) {
+	this.errors = append(this.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCompareProfiles_ReportsMissingProfile(t *testing.T) {
+	recorder := &errorRecorder{TB: t}
+	base := &Entry{Name: "base", CPUProfile: &profiling.Profile{}}
+	target := &Entry{Name: "target"}
+
+	deltas := CompareProfiles(recorder, base, target)
+
+	if deltas != nil {
+		t.Errorf("expected no deltas, got %v", deltas)
+	}
+
+	if len(recorder.errors) != 1 || !strings.Contains(recorder.errors[0], "'target' has no cpu profile") {
+		t.Errorf("expected an error naming the benchmark without a profile, got %q", recorder.errors)
+	}
+}
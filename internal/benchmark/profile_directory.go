@@ -0,0 +1,89 @@
+package benchmark
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RunDirectoryLayout is the time layout used to name the directory of each run
+// inside the profile directory.
+const RunDirectoryLayout = "20060102-150405"
+
+// RunDirectory names the directory for a run started at `start` inside the
+// profile directory `root`.
+func RunDirectory(root string, start time.Time) string {
+	return filepath.Join(root, start.Format(RunDirectoryLayout))
+}
+
+// SanitizeName turns a benchmark name into something that is safe to use as a
+// single file or directory name. Anything other than letters, digits, '-', '_'
+// and '.' is replaced with '_'. When anything was replaced, a short hash of the
+// original name is appended, so that names like "a b" and "a/b" do not end up
+// with the same profiles.
+func SanitizeName(name string) string {
+	sanitized := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+
+	// names like "." and ".." would escape the run directory
+	if strings.Trim(sanitized, ".") == "" {
+		sanitized = strings.Repeat("_", max(1, len(sanitized)))
+	}
+
+	if sanitized == name {
+		return sanitized
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name))
+	return fmt.Sprintf("%s-%08x", sanitized, hash.Sum32())
+}
+
+// PruneRuns removes the oldest run directories in `root` so that at most
+// `keep` remain. Only directories named with RunDirectoryLayout are
+// considered, and a `keep` of zero keeps everything.
+func PruneRuns(root string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return err
+	}
+
+	runs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		if _, err = time.Parse(RunDirectoryLayout, entry.Name()); err != nil {
+			continue
+		}
+
+		runs = append(runs, entry.Name())
+	}
+
+	// the layout sorts chronologically
+	slices.Sort(runs)
+	for len(runs) > keep {
+		if err = os.RemoveAll(filepath.Join(root, runs[0])); err != nil {
+			return err
+		}
+
+		runs = runs[1:]
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package benchmark
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSanitizeName(t *testing.T) {
+	tests := map[string]string{
+		"fib":                "fib",
+		"fib small":          "fib_small-f0906f31",
+		"strategy/a":         "strategy_a-e3076d90",
+		"..":                 "__-a3d4a70d",
+		"cache-hit_ratio.v2": "cache-hit_ratio.v2",
+		"émoji ✓":            "_moji__-7e6882af",
+	}
+
+	for name, expected := range tests {
+		actual := SanitizeName(name)
+		if actual != expected {
+			t.Errorf("SanitizeName(%q) is %q, want %q", name, actual, expected)
+		}
+	}
+}
+
+func TestSanitizeName_KeepsSimilarNamesApart(t *testing.T) {
+	sanitized := map[string]string{}
+	for _, name := range []string{"a_b", "a b", "a/b", "a:b"} {
+		actual := SanitizeName(name)
+		if previous, exists := sanitized[actual]; exists {
+			t.Errorf("SanitizeName(%q) and SanitizeName(%q) are both %q", previous, name, actual)
+		}
+
+		sanitized[actual] = name
+	}
+}
+
+func TestPruneRuns_KeepsNewestRuns(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"20240101-000000", "20240102-000000", "20240103-000000", "unrelated"} {
+		if err := os.Mkdir(filepath.Join(root, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := PruneRuns(root, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	actual := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		actual = append(actual, entry.Name())
+	}
+
+	expected := []string{"20240102-000000", "20240103-000000", "unrelated"}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v to remain, got %v", expected, actual)
+	}
+}
@@ -0,0 +1,55 @@
+package benchmark
+
+import (
+	"time"
+
+	"github.com/smarty/benchy/stats"
+)
+
+// Progress describes how far the sampling of a benchmark has come, after a
+// sample was taken.
+type Progress struct {
+	// Name is the name of the benchmark.
+	Name string
+
+	// Sample is the number of samples taken so far, starting at 1.
+	Sample int
+
+	// SampleCount is the number of samples that will be taken.
+	SampleCount int
+
+	// Duration is the duration of a single operation in the sample that was
+	// just taken.
+	Duration stats.Duration
+
+	// Iterations is the number of operations in the sample that was just taken.
+	Iterations int
+
+	// Allocations is the number of allocations per operation in the sample that
+	// was just taken.
+	Allocations float64
+
+	// MemoryGrowth is the number of allocations per operation in the sample that
+	// was just taken, which were still held after it.
+	MemoryGrowth float64
+
+	// Median is the median of the samples taken so far, including outliers.
+	Median stats.Duration
+
+	// Elapsed is the time spent sampling the benchmark so far.
+	Elapsed time.Duration
+
+	// Overhead is true when the sample was taken to measure the overhead of
+	// Benchy, rather than the benchmark function.
+	Overhead bool
+}
+
+// Remaining estimates the time left until all samples are taken, from the
+// average time per sample so far.
+func (this Progress) Remaining() time.Duration {
+	if this.Sample <= 0 {
+		return 0
+	}
+
+	return this.Elapsed / time.Duration(this.Sample) * time.Duration(max(0, this.SampleCount-this.Sample))
+}
@@ -1,10 +1,15 @@
 package benchmark
 
 import (
+	"context"
 	"fmt"
+	"runtime/pprof"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/smarty/benchy/internal/benchmark/strategies"
+	"github.com/smarty/benchy/internal/statistics"
 	"github.com/smarty/benchy/options"
 	"github.com/smarty/benchy/stats"
 )
@@ -27,7 +32,16 @@ func SampleOverhead(b *testing.B, entry *Entry, sampleCount int) {
 		BenchmarkFunction: func() {},
 		Cleanup:           entry.Cleanup,
 	}
-	result := sampleHelper(b, fmt.Sprintf("%s [overhead]", entry.Name), overHeadEntry, min(sampleCount, 5), stats.Duration(0))
+
+	if progress := entry.Progress; progress != nil {
+		overHeadEntry.Progress = func(sample Progress) {
+			sample.Name = entry.Name
+			sample.Overhead = true
+			progress(sample)
+		}
+	}
+
+	result, _ := sampleHelper(b, fmt.Sprintf("%s [overhead]", entry.Name), overHeadEntry, min(sampleCount, 5), stats.Duration(0))
 	stats.CalculateAverage(result)
 	entry.Overhead = result.Average
 }
@@ -40,19 +54,24 @@ func SampleOverhead(b *testing.B, entry *Entry, sampleCount int) {
 //   - entry contains benchmark information.
 //   - sampleCount is the number of samples for this benchmark.
 func Sample(b *testing.B, entry *Entry, sampleCount int) {
-	result := sampleHelper(b, entry.Name, entry, sampleCount, entry.Overhead)
+	result, pprofCPU := sampleHelper(b, entry.Name, entry, sampleCount, entry.Overhead)
+	result.BytesPerOp = entry.BytesPerOp
+	result.ItemsPerOp = entry.ItemsPerOp
 	stats.CalculateFullResultStatistics(result)
 
 	entry.Results = result
+	entry.CPUProfile = pprofCPU.MergedProfile()
 }
 
-func sampleHelper(b *testing.B, name string, entry *Entry, sampleCount int, overhead stats.Duration) *stats.BenchmarkResult {
+func sampleHelper(b *testing.B, name string, entry *Entry, sampleCount int, overhead stats.Duration) (*stats.BenchmarkResult, strategies.PProfCPUStrategy) {
 	var (
 		previousN   int
 		finalSample stats.Duration
 
-		memoryStats strategies.MemoryStatsStrategy
-		pprofCPU    strategies.PProfCPUStrategy = strategies.NewNullPProfCPU()
+		memoryStats   strategies.MemoryStatsStrategy
+		resourceUsage strategies.ResourceUsageStrategy
+		pprofCPU      strategies.PProfCPUStrategy = strategies.NewNullPProfCPU()
+		tracer        strategies.TraceStrategy    = strategies.NewNullTrace()
 	)
 
 	result := &stats.BenchmarkResult{
@@ -62,39 +81,140 @@ func sampleHelper(b *testing.B, name string, entry *Entry, sampleCount int, over
 	}
 
 	memoryStats = strategies.NewActiveMemoryStats()
+	resourceUsage = strategies.NewResourceUsage()
 	if entry.Flags.Contains(options.PProfCPU) {
-		pprofCPU = strategies.NewActivePProfCPU(b, name)
+		pprofCPU = strategies.NewActivePProfCPU(b, name, entry.ProfileDirectory, entry.Flags.Contains(options.PProfCPUSamples))
 	}
 
+	if entry.Flags.Contains(options.Trace) {
+		tracer = strategies.NewActiveTrace(b, name, entry.ProfileDirectory)
+	}
+
+	// entries which cannot report metrics record into an empty collection
+	metrics := entry.Metrics
+	if metrics == nil {
+		metrics = NewMetrics()
+	}
+
+	metrics.Clear()
+	started := time.Now()
 	for sample := 0; sample < sampleCount; sample++ {
 		finalSample = 0
 		previousN = 0
 
+		tracer.StartRecording()
 		b.Run(name, func(b *testing.B) {
+			// the task, the labels and the prefetch function allocate, so they
+			// are prepared while the timer is stopped
+			b.StopTimer()
+			ctx := tracer.StartTask(sample, b.N)
+			phases := newPhaseLabels(ctx, name, sample)
+			untimed := func(function func()) {
+				b.StopTimer()
+				memoryStats.Pause()
+				resourceUsage.Pause()
+				function()
+				resourceUsage.Resume()
+				memoryStats.Resume()
+				b.StartTimer()
+			}
+
+			b.StartTimer()
+			if entry.Prefetch != nil {
+				entry.Prefetch(untimed)
+			}
+
 			pprofCPU.StartRecording()
 			memoryStats.SetStartingStats()
-			entry.Setup()
+			resourceUsage.SetStartingStats()
+			metrics.Reset()
+			runPhase(phases.setup, tracer, "setup", entry.Setup)
+			pprof.SetGoroutineLabels(phases.batch)
+			tracer.StartRegion(phases.batch, "batch")
 			for i := 0; i < b.N; i++ {
 				entry.BenchmarkFunction()
 			}
 
+			tracer.EndRegion()
+
 			if b.N < previousN {
 				result.Samples = append(result.Samples, finalSample)
 			}
 
 			previousN = b.N
 			finalSample = stats.Duration(b.Elapsed().Nanoseconds()) / stats.Duration(b.N)
+			resourceUsage.SetEndingStats()
 			memoryStats.SetEndingStats()
 			pprofCPU.StopRecording()
-			entry.Cleanup()
+			runPhase(phases.cleanup, tracer, "cleanup", entry.Cleanup)
+			pprof.SetGoroutineLabels(ctx)
+			tracer.EndTask()
 		})
 
+		tracer.StopRecording()
 		pprofCPU.WriteRecording()
+		tracer.WriteRecording()
 		result.Samples = append(result.Samples, max(0, finalSample-overhead))
 		memoryStats.CommitStats(previousN)
+		resourceUsage.CommitStats(previousN)
+		metrics.Commit(previousN)
+		if entry.Progress != nil {
+			allocations, memoryGrowth := memoryStats.LastStats()
+			entry.Progress(Progress{
+				Name:         name,
+				Sample:       sample + 1,
+				SampleCount:  sampleCount,
+				Duration:     result.Samples[len(result.Samples)-1],
+				Iterations:   previousN,
+				Allocations:  allocations,
+				MemoryGrowth: memoryGrowth,
+				Median:       statistics.Median(result.Samples),
+				Elapsed:      time.Since(started),
+			})
+		}
 	}
 
+	pprofCPU.WriteMergedRecording()
 	pprofCPU.WriteRunnerScript()
+	pprofCPU.WriteTo(result)
 	memoryStats.WriteTo(result, sampleCount)
-	return result
+	resourceUsage.WriteTo(result, sampleCount)
+	metrics.WriteTo(result)
+	return result, pprofCPU
+}
+
+// phaseLabels carries the pprof labels "benchy.benchmark", "benchy.sample"
+// and "benchy.phase" of every phase of a sample, so that both profilers
+// started by Benchy and external profilers (for example, `-cpuprofile` from the
+// CLI) can attribute their samples. They are built while the timer of the
+// sample is stopped and before its allocations are counted, because building
+// them allocates.
+type phaseLabels struct {
+	setup   context.Context
+	batch   context.Context
+	cleanup context.Context
+}
+
+func newPhaseLabels(ctx context.Context, name string, sample int) phaseLabels {
+	withPhase := func(phase string) context.Context {
+		return pprof.WithLabels(ctx, pprof.Labels(
+			"benchy.benchmark", name,
+			"benchy.sample", strconv.Itoa(sample),
+			"benchy.phase", phase))
+	}
+
+	return phaseLabels{
+		setup:   withPhase("setup"),
+		batch:   withPhase("batch"),
+		cleanup: withPhase("cleanup"),
+	}
+}
+
+// runPhase runs one phase of a sample with the labels of `ctx` and inside a
+// trace region named after the phase.
+func runPhase(ctx context.Context, tracer strategies.TraceStrategy, phase string, function func()) {
+	pprof.SetGoroutineLabels(ctx)
+	tracer.StartRegion(ctx, phase)
+	function()
+	tracer.EndRegion()
 }
@@ -0,0 +1,92 @@
+package benchmark
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/smarty/benchy/internal/assertions"
+	"github.com/smarty/benchy/stats"
+)
+
+var (
+	sink     int
+	retained [][]byte
+)
+
+// setBenchtime sets a fixed number of iterations per sample for the duration
+// of the test, so that sampling does not take seconds.
+func setBenchtime(t *testing.T, benchtime string) {
+	benchtimeFlag := flag.Lookup("test.benchtime")
+	previous := benchtimeFlag.Value.String()
+	if err := benchtimeFlag.Value.Set(benchtime); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = benchtimeFlag.Value.Set(previous) })
+}
+
+func TestSample_AllocationFreeFunctionDoesNotAllocate(t *testing.T) {
+	setBenchtime(t, "1000x")
+	entry := &Entry{
+		Name:              "add",
+		Setup:             func() {},
+		BenchmarkFunction: func() { sink++ },
+		Cleanup:           func() {},
+	}
+
+	var result *stats.BenchmarkResult
+	testing.Benchmark(func(b *testing.B) {
+		result, _ = sampleHelper(b, entry.Name, entry, 3, 0)
+	})
+
+	if err := assertions.IsNonAllocating(result); err != nil {
+		t.Errorf("%v (%g allocations per operation)", err, result.Allocations)
+	}
+
+	if result.MemoryGrowth != 0 {
+		t.Errorf("expected no memory growth, got %g", result.MemoryGrowth)
+	}
+}
+
+func TestSample_PrefetchIsNotMeasured(t *testing.T) {
+	setBenchtime(t, "100x")
+	var untimed func(function func())
+	prefetched := 0
+	prefetch := func() {
+		retained = append(retained, make([]byte, 1024))
+		prefetched++
+	}
+
+	entry := &Entry{
+		Name:  "prefetched",
+		Setup: func() {},
+		Prefetch: func(function func(function func())) {
+			untimed = function
+			untimed(prefetch)
+		},
+		BenchmarkFunction: func() {
+			// run out of values every ten operations
+			if sink++; sink%10 == 0 {
+				untimed(prefetch)
+			}
+		},
+		Cleanup: func() {},
+	}
+
+	var result *stats.BenchmarkResult
+	testing.Benchmark(func(b *testing.B) {
+		result, _ = sampleHelper(b, entry.Name, entry, 3, 0)
+	})
+
+	if prefetched == 0 {
+		t.Fatal("expected the prefetch to run")
+	}
+
+	if err := assertions.IsNonAllocating(result); err != nil {
+		t.Errorf("%v (%g allocations per operation)", err, result.Allocations)
+	}
+
+	if result.MemoryGrowth != 0 {
+		t.Errorf("expected no memory growth, got %g", result.MemoryGrowth)
+	}
+}
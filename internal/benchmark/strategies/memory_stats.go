@@ -15,6 +15,13 @@ type MemoryStatsStrategy interface {
 	// SetEndingStats sets the memory usage statistics after a benchmark is run.
 	SetEndingStats()
 
+	// Pause stops counting allocations until Resume is called, so that work
+	// which is not part of the benchmark can run in between.
+	Pause()
+
+	// Resume counts allocations again after Pause.
+	Resume()
+
 	// CommitStats adds the most recently calculated stats to internal values
 	// for averaging and writing later.
 	//
@@ -22,6 +29,10 @@ type MemoryStatsStrategy interface {
 	//   - n is the number of cycles in the last benchmark run.
 	CommitStats(n int)
 
+	// LastStats returns the stats per cycle of the most recently committed
+	// benchmark run.
+	LastStats() (allocations float64, memoryGrowth float64)
+
 	// WriteTo averages the values and writes the average to the
 	// `result.`
 	//
@@ -39,11 +50,19 @@ type ActiveMemoryStats struct {
 	allocations  float64
 	memoryGrowth float64
 
+	lastAllocations  float64
+	lastMemoryGrowth float64
+
 	startAllocs uint64
 	endAllocs   uint64
 
 	startFrees uint64
 	endFrees   uint64
+
+	pausedAllocs  uint64
+	pausedFrees   uint64
+	skippedAllocs uint64
+	skippedFrees  uint64
 }
 
 func NewActiveMemoryStats() *ActiveMemoryStats {
@@ -56,6 +75,8 @@ func (this *ActiveMemoryStats) SetStartingStats() {
 	runtime.ReadMemStats(this.memoryStats)
 	this.startAllocs = this.memoryStats.Mallocs
 	this.startFrees = this.memoryStats.Frees
+	this.skippedAllocs = 0
+	this.skippedFrees = 0
 }
 
 func (this *ActiveMemoryStats) SetEndingStats() {
@@ -64,9 +85,29 @@ func (this *ActiveMemoryStats) SetEndingStats() {
 	this.endFrees = this.memoryStats.Frees
 }
 
+func (this *ActiveMemoryStats) Pause() {
+	runtime.ReadMemStats(this.memoryStats)
+	this.pausedAllocs = this.memoryStats.Mallocs
+	this.pausedFrees = this.memoryStats.Frees
+}
+
+func (this *ActiveMemoryStats) Resume() {
+	runtime.ReadMemStats(this.memoryStats)
+	this.skippedAllocs += this.memoryStats.Mallocs - this.pausedAllocs
+	this.skippedFrees += this.memoryStats.Frees - this.pausedFrees
+}
+
 func (this *ActiveMemoryStats) CommitStats(n int) {
-	this.allocations += float64(this.endAllocs-this.startAllocs) / float64(n)
-	this.memoryGrowth += float64((this.endAllocs-this.endFrees)-(this.startAllocs-this.startFrees)) / float64(n)
+	allocs := this.endAllocs - this.startAllocs - this.skippedAllocs
+	frees := this.endFrees - this.startFrees - this.skippedFrees
+	this.lastAllocations = float64(allocs) / float64(n)
+	this.lastMemoryGrowth = (float64(allocs) - float64(frees)) / float64(n)
+	this.allocations += this.lastAllocations
+	this.memoryGrowth += this.lastMemoryGrowth
+}
+
+func (this *ActiveMemoryStats) LastStats() (allocations float64, memoryGrowth float64) {
+	return this.lastAllocations, this.lastMemoryGrowth
 }
 
 func (this *ActiveMemoryStats) WriteTo(result *stats.BenchmarkResult, sampleCount int) {
@@ -85,5 +126,8 @@ func NewNullMemoryStats() *NullMemoryStats {
 
 func (this *NullMemoryStats) SetStartingStats()                                      {}
 func (this *NullMemoryStats) SetEndingStats()                                        {}
+func (this *NullMemoryStats) Pause()                                                 {}
+func (this *NullMemoryStats) Resume()                                                {}
 func (this *NullMemoryStats) CommitStats(n int)                                      {}
+func (this *NullMemoryStats) LastStats() (float64, float64)                          { return 0, 0 }
 func (this *NullMemoryStats) WriteTo(result *stats.BenchmarkResult, sampleCount int) {}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime/pprof"
 	"strings"
 	"testing"
-	"time"
+
+	"github.com/smarty/benchy/internal/profiling"
+	"github.com/smarty/benchy/stats"
 )
 
 // PProfCPUStrategy records CPU PProf readings and writes them to disk.
@@ -18,25 +21,56 @@ type PProfCPUStrategy interface {
 	// StopRecording stops the recording PProf for the CPU.
 	StopRecording()
 
-	// WriteRecording writes the most recent recording to disk.
+	// WriteRecording keeps the most recent recording for merging, and writes
+	// it to disk when per-sample files are requested.
 	WriteRecording()
 
-	// WriteRunnerScript writes a bash script that will gather all the PProf
-	// files when running for convenient analysis.
+	// WriteMergedRecording merges all kept recordings into a single profile
+	// and writes it to disk.
+	WriteMergedRecording()
+
+	// WriteRunnerScript writes a bash script that will open the merged PProf
+	// file when running for convenient analysis.
 	WriteRunnerScript()
+
+	// WriteTo summarizes the merged profile into the hot functions of the
+	// `result`.
+	//
+	// Parameters:
+	//   - result is the instance to write to.
+	WriteTo(result *stats.BenchmarkResult)
+
+	// MergedProfile returns the profile written by WriteMergedRecording, or
+	// `nil` when there is none.
+	MergedProfile() *profiling.Profile
 }
 
+// Permissions used for everything written to the profile directory.
+const (
+	DirectoryPermissions = 0755
+	FilePermissions      = 0644
+	ScriptPermissions    = 0755
+)
+
+// MergedProfileFilename is the name of the file that the merged CPU profile of
+// all samples is written to.
+const MergedProfileFilename = "cpu_merged.pprof"
+
 // ---- Active ------
 
 type ActivePProfCPU struct {
-	b              *testing.B
+	b              testing.TB
 	name           string
 	saveDirectory  string
+	writeSamples   bool
 	currentProfile bytes.Buffer
+	recording      bool
+	profiles       []*profiling.Profile
+	merged         *profiling.Profile
 	totalFiles     int
 }
 
-func NewActivePProfCPU(b *testing.B, name string) PProfCPUStrategy {
+func NewActivePProfCPU(b testing.TB, name string, saveDirectory string, writeSamples bool) PProfCPUStrategy {
 	// if a pprof is already running, then fail the benchmark and return the
 	// null version
 	err := pprof.StartCPUProfile(&bytes.Buffer{})
@@ -47,30 +81,58 @@ func NewActivePProfCPU(b *testing.B, name string) PProfCPUStrategy {
 
 	pprof.StopCPUProfile()
 
-	pathName := fmt.Sprintf("./workspace/%s/%s/", time.Now().Format("20060102-150405"), name)
-	os.MkdirAll(pathName, 0777)
+	if err = os.MkdirAll(saveDirectory, DirectoryPermissions); err != nil {
+		b.Errorf("cannot create the profile directory for benchmark '%s': %v", name, err)
+		return &NullPProfCPU{}
+	}
 
 	return &ActivePProfCPU{
 		b:             b,
 		name:          name,
-		saveDirectory: pathName,
+		saveDirectory: saveDirectory,
+		writeSamples:  writeSamples,
 	}
 }
 
 func (this *ActivePProfCPU) StartRecording() {
 	this.currentProfile.Reset()
-	pprof.StartCPUProfile(&this.currentProfile)
+	this.recording = false
+	if err := pprof.StartCPUProfile(&this.currentProfile); err != nil {
+		// another profile was started since the benchmark began, and must keep
+		// running, so this sample is not recorded
+		this.b.Errorf("cannot record the cpu profile of a sample of '%s': %v", this.name, err)
+		return
+	}
+
+	this.recording = true
 }
 
 func (this *ActivePProfCPU) StopRecording() {
-	pprof.StopCPUProfile()
+	if this.recording {
+		pprof.StopCPUProfile()
+	}
 }
 
 func (this *ActivePProfCPU) WriteRecording() {
-	err := os.WriteFile(
-		fmt.Sprintf("%s/cpu_%d.pprof", this.saveDirectory, this.totalFiles),
+	if !this.recording {
+		return
+	}
+
+	profile, err := profiling.Parse(this.currentProfile.Bytes())
+	if err != nil {
+		this.b.Errorf("could not read the cpu profile of '%s': %v", this.name, err)
+		return
+	}
+
+	this.profiles = append(this.profiles, profile)
+	if !this.writeSamples {
+		return
+	}
+
+	err = os.WriteFile(
+		filepath.Join(this.saveDirectory, fmt.Sprintf("cpu_%d.pprof", this.totalFiles)),
 		this.currentProfile.Bytes(),
-		0777)
+		FilePermissions)
 
 	this.totalFiles++
 	if err != nil {
@@ -79,14 +141,69 @@ func (this *ActivePProfCPU) WriteRecording() {
 	}
 }
 
+func (this *ActivePProfCPU) WriteMergedRecording() {
+	if len(this.profiles) == 0 {
+		return
+	}
+
+	merged, err := profiling.Merge(this.profiles...)
+	if err != nil {
+		this.b.Errorf("could not merge the cpu profiles of '%s': %v", this.name, err)
+		return
+	}
+
+	buffer := &bytes.Buffer{}
+	if _, err = merged.WriteTo(buffer); err != nil {
+		this.b.Error(err)
+		return
+	}
+
+	this.merged = merged
+	err = os.WriteFile(filepath.Join(this.saveDirectory, MergedProfileFilename), buffer.Bytes(), FilePermissions)
+	if err != nil {
+		this.b.Error(err)
+	}
+}
+
 func (this *ActivePProfCPU) WriteRunnerScript() {
 	sb := strings.Builder{}
-	sb.WriteString("#!/bin/bash\ngo tool pprof -http localhost:8080")
-	for i := range this.totalFiles {
-		sb.WriteString(fmt.Sprintf(" cpu_%d.pprof", i))
+	sb.WriteString("#!/bin/bash\ngo tool pprof -http localhost:8080 ")
+	sb.WriteString(MergedProfileFilename)
+
+	err := os.WriteFile(filepath.Join(this.saveDirectory, "pprof.sh"), []byte(sb.String()), ScriptPermissions)
+	if err != nil {
+		this.b.Error(err)
+	}
+}
+
+func (this *ActivePProfCPU) WriteTo(result *stats.BenchmarkResult) {
+	if this.merged == nil {
+		return
 	}
 
-	os.WriteFile(fmt.Sprintf("%s/%s", this.saveDirectory, "pprof.sh"), []byte(sb.String()), 0777)
+	valueIndex, found := this.merged.ValueIndex("cpu")
+	if !found {
+		// a profile without sample types was recorded, but has nothing to show
+		result.HotFunctions = []stats.HotFunction{}
+		return
+	}
+
+	total := float64(max(1, this.merged.Total(valueIndex)))
+	functionTotals := this.merged.FunctionTotals(valueIndex)
+	result.HotFunctions = make([]stats.HotFunction, 0, len(functionTotals))
+	for _, functionTotal := range functionTotals {
+		result.HotFunctions = append(result.HotFunctions, stats.HotFunction{
+			Name:              functionTotal.Name,
+			Flat:              stats.Duration(functionTotal.Flat),
+			FlatPercent:       float64(functionTotal.Flat) / total * 100,
+			Cumulative:        stats.Duration(functionTotal.Cumulative),
+			CumulativePercent: float64(functionTotal.Cumulative) / total * 100,
+		})
+	}
+}
+
+func (this *ActivePProfCPU) MergedProfile() *profiling.Profile {
+	return this.merged
 }
 
 // ---- NULL ------
@@ -98,7 +215,10 @@ func NewNullPProfCPU() *NullPProfCPU {
 	return &NullPProfCPU{}
 }
 
-func (this *NullPProfCPU) StartRecording()    {}
-func (this *NullPProfCPU) StopRecording()     {}
-func (this *NullPProfCPU) WriteRecording()    {}
-func (this *NullPProfCPU) WriteRunnerScript() {}
+func (this *NullPProfCPU) StartRecording()                       {}
+func (this *NullPProfCPU) StopRecording()                        {}
+func (this *NullPProfCPU) WriteRecording()                       {}
+func (this *NullPProfCPU) WriteMergedRecording()                 {}
+func (this *NullPProfCPU) WriteRunnerScript()                    {}
+func (this *NullPProfCPU) WriteTo(result *stats.BenchmarkResult) {}
+func (this *NullPProfCPU) MergedProfile() *profiling.Profile     { return nil }
@@ -0,0 +1,57 @@
+package strategies
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"testing"
+)
+
+func recordCPUSample(profiler PProfCPUStrategy) {
+	profiler.StartRecording()
+	profiler.StopRecording()
+	profiler.WriteRecording()
+}
+
+func TestActivePProfCPU_SkipsSampleWhenAnotherProfileStarted(t *testing.T) {
+	recorder := &errorRecorder{TB: t}
+	profiler := NewActivePProfCPU(recorder, "sample", t.TempDir(), false)
+	if len(recorder.errors) > 0 {
+		t.Skip("cpu profiling is not available:", recorder.errors)
+	}
+
+	outer := &bytes.Buffer{}
+	if err := pprof.StartCPUProfile(outer); err != nil {
+		t.Skip("cpu profiling is not available:", err)
+	}
+
+	recordCPUSample(profiler)
+	stillRunning := pprof.StartCPUProfile(&bytes.Buffer{}) != nil
+	pprof.StopCPUProfile()
+
+	if len(recorder.errors) != 1 {
+		t.Errorf("expected a single error for the skipped sample, got %q", recorder.errors)
+	}
+
+	if !stillRunning {
+		t.Error("expected the other cpu profile to keep running")
+	}
+
+	if len(profiler.(*ActivePProfCPU).profiles) != 0 {
+		t.Error("expected the skipped sample not to be kept for merging")
+	}
+}
+
+func TestActivePProfCPU_KeepsRecording(t *testing.T) {
+	recorder := &errorRecorder{TB: t}
+	profiler := NewActivePProfCPU(recorder, "sample", t.TempDir(), false)
+
+	recordCPUSample(profiler)
+
+	if len(recorder.errors) > 0 {
+		t.Fatalf("expected no errors, got %q", recorder.errors)
+	}
+
+	if len(profiler.(*ActivePProfCPU).profiles) != 1 {
+		t.Error("expected the sample to be kept for merging")
+	}
+}
@@ -0,0 +1,53 @@
+package strategies
+
+import (
+	"github.com/smarty/benchy/stats"
+)
+
+// ResourceUsageStrategy calculates resource usage statistics of the process,
+// such as CPU time and context switches.
+type ResourceUsageStrategy interface {
+	// SetStartingStats sets the resource usage before a benchmark is run.
+	SetStartingStats()
+
+	// SetEndingStats sets the resource usage after a benchmark is run.
+	SetEndingStats()
+
+	// Pause stops counting resource usage until Resume is called, so that
+	// work which is not part of the benchmark can run in between.
+	Pause()
+
+	// Resume counts resource usage again after Pause.
+	Resume()
+
+	// CommitStats adds the most recently calculated stats to internal values
+	// for averaging and writing later.
+	//
+	// Parameters:
+	//   - n is the number of cycles in the last benchmark run.
+	CommitStats(n int)
+
+	// WriteTo averages the values and writes the average to the
+	// `result.`
+	//
+	// Parameters:
+	//   - result is the instance to write to.
+	//   - sampleCount is the number of samples taken.
+	WriteTo(result *stats.BenchmarkResult, sampleCount int)
+}
+
+// ----- NULL ------
+
+type NullResourceUsage struct {
+}
+
+func NewNullResourceUsage() *NullResourceUsage {
+	return &NullResourceUsage{}
+}
+
+func (this *NullResourceUsage) SetStartingStats()                                      {}
+func (this *NullResourceUsage) SetEndingStats()                                        {}
+func (this *NullResourceUsage) Pause()                                                 {}
+func (this *NullResourceUsage) Resume()                                                {}
+func (this *NullResourceUsage) CommitStats(n int)                                      {}
+func (this *NullResourceUsage) WriteTo(result *stats.BenchmarkResult, sampleCount int) {}
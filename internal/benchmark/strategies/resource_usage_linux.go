@@ -0,0 +1,76 @@
+//go:build linux
+
+package strategies
+
+import (
+	"syscall"
+
+	"github.com/smarty/benchy/stats"
+)
+
+// NewResourceUsage returns the resource usage strategy for this platform.
+func NewResourceUsage() ResourceUsageStrategy {
+	return NewActiveResourceUsage()
+}
+
+// ----- Active ------
+
+type ActiveResourceUsage struct {
+	start   syscall.Rusage
+	end     syscall.Rusage
+	paused  syscall.Rusage
+	skipped syscall.Rusage
+
+	userTime                   float64
+	systemTime                 float64
+	voluntaryContextSwitches   float64
+	involuntaryContextSwitches float64
+	minorPageFaults            float64
+	majorPageFaults            float64
+}
+
+func NewActiveResourceUsage() *ActiveResourceUsage {
+	return &ActiveResourceUsage{}
+}
+
+func (this *ActiveResourceUsage) SetStartingStats() {
+	_ = syscall.Getrusage(syscall.RUSAGE_SELF, &this.start)
+	this.skipped = syscall.Rusage{}
+}
+
+func (this *ActiveResourceUsage) SetEndingStats() {
+	_ = syscall.Getrusage(syscall.RUSAGE_SELF, &this.end)
+}
+
+func (this *ActiveResourceUsage) Pause() {
+	_ = syscall.Getrusage(syscall.RUSAGE_SELF, &this.paused)
+}
+
+func (this *ActiveResourceUsage) Resume() {
+	var resumed syscall.Rusage
+	_ = syscall.Getrusage(syscall.RUSAGE_SELF, &resumed)
+	this.skipped.Utime = syscall.NsecToTimeval(this.skipped.Utime.Nano() + resumed.Utime.Nano() - this.paused.Utime.Nano())
+	this.skipped.Stime = syscall.NsecToTimeval(this.skipped.Stime.Nano() + resumed.Stime.Nano() - this.paused.Stime.Nano())
+	this.skipped.Nvcsw += resumed.Nvcsw - this.paused.Nvcsw
+	this.skipped.Nivcsw += resumed.Nivcsw - this.paused.Nivcsw
+	this.skipped.Minflt += resumed.Minflt - this.paused.Minflt
+	this.skipped.Majflt += resumed.Majflt - this.paused.Majflt
+}
+
+func (this *ActiveResourceUsage) CommitStats(n int) {
+	this.userTime += float64(this.end.Utime.Nano()-this.start.Utime.Nano()-this.skipped.Utime.Nano()) / float64(n)
+	this.systemTime += float64(this.end.Stime.Nano()-this.start.Stime.Nano()-this.skipped.Stime.Nano()) / float64(n)
+	this.voluntaryContextSwitches += float64(this.end.Nvcsw-this.start.Nvcsw-this.skipped.Nvcsw) / float64(n)
+	this.involuntaryContextSwitches += float64(this.end.Nivcsw-this.start.Nivcsw-this.skipped.Nivcsw) / float64(n)
+	this.minorPageFaults += float64(this.end.Minflt-this.start.Minflt-this.skipped.Minflt) / float64(n)
+	this.majorPageFaults += float64(this.end.Majflt-this.start.Majflt-this.skipped.Majflt) / float64(n)
+}
+
+func (this *ActiveResourceUsage) WriteTo(result *stats.BenchmarkResult, sampleCount int) {
+	result.UserTime = stats.Duration(this.userTime / float64(sampleCount))
+	result.SystemTime = stats.Duration(this.systemTime / float64(sampleCount))
+	result.VoluntaryContextSwitches = this.voluntaryContextSwitches / float64(sampleCount)
+	result.InvoluntaryContextSwitches = this.involuntaryContextSwitches / float64(sampleCount)
+	result.MinorPageFaults = this.minorPageFaults / float64(sampleCount)
+	result.MajorPageFaults = this.majorPageFaults / float64(sampleCount)
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package strategies
+
+// NewResourceUsage returns the resource usage strategy for this platform.
+// Resource usage is only recorded on Linux.
+func NewResourceUsage() ResourceUsageStrategy {
+	return NewNullResourceUsage()
+}
@@ -0,0 +1,164 @@
+package strategies
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/trace"
+	"strconv"
+	"testing"
+)
+
+// TraceStrategy records execution traces and writes them to disk.
+type TraceStrategy interface {
+	// StartRecording starts an execution trace for the sample. It is called
+	// before the sample is timed.
+	StartRecording()
+
+	// StartTask starts a task for a run of the sample, which carries the
+	// regions of its phases.
+	//
+	// Parameters:
+	//   - sample is the index of the sample being recorded.
+	//   - n is the number of iterations in this batch.
+	//
+	// Returns:
+	//   - ctx carries the task, for annotating the rest of the sample.
+	StartTask(sample int, n int) (ctx context.Context)
+
+	// StartRegion starts a trace region named `name`, which lasts until
+	// EndRegion is called.
+	StartRegion(ctx context.Context, name string)
+
+	// EndRegion ends the most recently started region.
+	EndRegion()
+
+	// EndTask ends the task of the run.
+	EndTask()
+
+	// StopRecording stops the execution trace. It is called after the sample
+	// is timed.
+	StopRecording()
+
+	// WriteRecording writes the most recent recording to disk.
+	WriteRecording()
+}
+
+// ---- Active ------
+
+type ActiveTrace struct {
+	tb            testing.TB
+	name          string
+	saveDirectory string
+	currentTrace  bytes.Buffer
+	recording     bool
+	task          *trace.Task
+	region        *trace.Region
+	totalFiles    int
+}
+
+func NewActiveTrace(tb testing.TB, name string, saveDirectory string) TraceStrategy {
+	// if a trace is already running, then fail the benchmark and return the
+	// null version
+	if trace.IsEnabled() {
+		tb.Errorf("an execution trace is already running, cannot trace benchmark '%s'", name)
+		return &NullTrace{}
+	}
+
+	if err := os.MkdirAll(saveDirectory, DirectoryPermissions); err != nil {
+		tb.Errorf("cannot create the profile directory for benchmark '%s': %v", name, err)
+		return &NullTrace{}
+	}
+
+	return &ActiveTrace{
+		tb:            tb,
+		name:          name,
+		saveDirectory: saveDirectory,
+	}
+}
+
+func (this *ActiveTrace) StartRecording() {
+	this.currentTrace.Reset()
+	this.recording = false
+	if err := trace.Start(&this.currentTrace); err != nil {
+		// another trace, such as `-trace` from the CLI, must keep running
+		this.tb.Errorf("cannot trace benchmark '%s': %v", this.name, err)
+		return
+	}
+
+	this.recording = true
+}
+
+func (this *ActiveTrace) StartTask(sample int, n int) context.Context {
+	if !this.recording {
+		return context.Background()
+	}
+
+	var ctx context.Context
+	ctx, this.task = trace.NewTask(context.Background(), fmt.Sprintf("%s sample %d", this.name, sample))
+	trace.Log(ctx, "benchy.n", strconv.Itoa(n))
+	return ctx
+}
+
+func (this *ActiveTrace) StartRegion(ctx context.Context, name string) {
+	if this.recording {
+		this.region = trace.StartRegion(ctx, name)
+	}
+}
+
+func (this *ActiveTrace) EndRegion() {
+	if this.region != nil {
+		this.region.End()
+		this.region = nil
+	}
+}
+
+func (this *ActiveTrace) EndTask() {
+	if this.task != nil {
+		this.task.End()
+		this.task = nil
+	}
+}
+
+func (this *ActiveTrace) StopRecording() {
+	if this.recording {
+		trace.Stop()
+	}
+}
+
+func (this *ActiveTrace) WriteRecording() {
+	if !this.recording {
+		return
+	}
+
+	err := os.WriteFile(
+		filepath.Join(this.saveDirectory, fmt.Sprintf("trace_%d.out", this.totalFiles)),
+		this.currentTrace.Bytes(),
+		FilePermissions)
+
+	this.totalFiles++
+	if err != nil {
+		this.tb.Error(err)
+	}
+}
+
+// ---- NULL ------
+
+type NullTrace struct {
+}
+
+func NewNullTrace() *NullTrace {
+	return &NullTrace{}
+}
+
+func (this *NullTrace) StartRecording() {}
+func (this *NullTrace) StartTask(sample int, n int) context.Context {
+	return context.Background()
+}
+func (this *NullTrace) StartRegion(ctx context.Context, name string) {}
+func (this *NullTrace) EndRegion()                                   {}
+func (this *NullTrace) EndTask()                                     {}
+func (this *NullTrace) StopRecording()                               {}
+func (this *NullTrace) WriteRecording()                              {}
@@ -0,0 +1,81 @@
+package strategies
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/trace"
+	"testing"
+)
+
+// errorRecorder records the messages of Errorf, without failing the test.
+type errorRecorder struct {
+	testing.TB
+	errors []string
+}
+
+func (this *errorRecorder) Errorf(format string, args ...any) {
+	this.errors = append(this.errors, fmt.Sprintf(format, args...))
+}
+
+func (this *errorRecorder) Error(args ...any) {
+	this.errors = append(this.errors, fmt.Sprint(args...))
+}
+
+func recordSample(tracer TraceStrategy) {
+	tracer.StartRecording()
+	ctx := tracer.StartTask(0, 1)
+	tracer.StartRegion(ctx, "batch")
+	tracer.EndRegion()
+	tracer.EndTask()
+	tracer.StopRecording()
+	tracer.WriteRecording()
+}
+
+func TestActiveTrace_WritesRecording(t *testing.T) {
+	directory := t.TempDir()
+	recorder := &errorRecorder{TB: t}
+	tracer := NewActiveTrace(recorder, "sample", directory)
+
+	recordSample(tracer)
+
+	if len(recorder.errors) > 0 {
+		t.Fatalf("expected no errors, got %v", recorder.errors)
+	}
+
+	if trace.IsEnabled() {
+		t.Error("expected the trace to be stopped")
+	}
+
+	contents, err := os.ReadFile(filepath.Join(directory, "trace_0.out"))
+	if err != nil || len(contents) == 0 {
+		t.Errorf("expected a recording to be written, got %d bytes and error %v", len(contents), err)
+	}
+}
+
+func TestActiveTrace_KeepsOtherTraceRunningWhenStartFails(t *testing.T) {
+	directory := t.TempDir()
+	recorder := &errorRecorder{TB: t}
+	tracer := NewActiveTrace(recorder, "sample", directory)
+	other := &bytes.Buffer{}
+	if err := trace.Start(other); err != nil {
+		t.Fatal(err)
+	}
+
+	defer trace.Stop()
+
+	recordSample(tracer)
+
+	if len(recorder.errors) != 1 {
+		t.Errorf("expected the failed start to be reported once, got %v", recorder.errors)
+	}
+
+	if !trace.IsEnabled() {
+		t.Error("expected the other trace to keep running")
+	}
+
+	if _, err := os.Stat(filepath.Join(directory, "trace_0.out")); !os.IsNotExist(err) {
+		t.Errorf("expected no recording to be written, got %v", err)
+	}
+}
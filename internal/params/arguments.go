@@ -0,0 +1,27 @@
+package params
+
+import (
+	"strings"
+)
+
+// findArgument looks for the flag `name` in `args`, written either as
+// `-name value` or as `-name=value`.
+func findArgument(args []string, name string) (value string, found bool) {
+	for iArgument, argument := range args {
+		if flagName, flagValue, hasValue := strings.Cut(argument, "="); hasValue && strings.EqualFold(flagName, name) {
+			return flagValue, true
+		}
+
+		if !strings.EqualFold(argument, name) {
+			continue
+		}
+
+		if iArgument == len(args)-1 {
+			break
+		}
+
+		return args[iArgument+1], true
+	}
+
+	return "", false
+}
@@ -0,0 +1,38 @@
+package params
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/smarty/benchy/options"
+)
+
+var _ = flag.String("test.benchy.color", "auto", "When to print stats in color: auto, always or never.")
+
+// SelectColorMode looks for a user-defined color mode from the input `args`
+// first. Then looks at `noColor`, the value of the `NO_COLOR` environment
+// variable, which turns color off when it is not empty. Finally, uses `input`.
+func SelectColorMode(input options.ColorMode, args []string, noColor string) options.ColorMode {
+	if argument, found := findArgument(args, "-test.benchy.color"); found {
+		switch strings.ToLower(argument) {
+		case "always":
+			return options.ColorAlways
+
+		case "never":
+			return options.ColorNever
+
+		case "auto":
+			if noColor != "" {
+				return options.ColorNever
+			}
+
+			return options.ColorAuto
+		}
+	}
+
+	if noColor != "" {
+		return options.ColorNever
+	}
+
+	return input
+}
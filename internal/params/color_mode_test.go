@@ -0,0 +1,50 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/smarty/benchy/options"
+)
+
+func Test_SelectColorMode_FromCLI(t *testing.T) {
+	expected := options.ColorAlways
+	args := []string{"-test.benchy.color=always"}
+
+	actual := SelectColorMode(options.ColorNever, args, "1")
+
+	if actual != expected {
+		t.Errorf("SelectColorMode() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_SelectColorMode_FromCLI_Never(t *testing.T) {
+	expected := options.ColorNever
+	args := []string{"-test.benchy.color", "never"}
+
+	actual := SelectColorMode(options.ColorAlways, args, "")
+
+	if actual != expected {
+		t.Errorf("SelectColorMode() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_SelectColorMode_NoColorEnvironment(t *testing.T) {
+	expected := options.ColorNever
+
+	actual := SelectColorMode(options.ColorAlways, nil, "1")
+
+	if actual != expected {
+		t.Errorf("SelectColorMode() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_SelectColorMode_FromInput(t *testing.T) {
+	expected := options.ColorAlways
+	args := []string{"-test.benchy.color=unknown"}
+
+	actual := SelectColorMode(expected, args, "")
+
+	if actual != expected {
+		t.Errorf("SelectColorMode() is %v, want %v", actual, expected)
+	}
+}
@@ -0,0 +1,19 @@
+package params
+
+import (
+	"flag"
+)
+
+var _ = flag.String("test.benchy.metrics", "", "Address that a Prometheus /metrics endpoint is served on during a run, such as localhost:9090.")
+
+// SelectMetricsAddress looks for a user-defined address for the metrics
+// endpoint from the input `args` first. Then looks at `input`.
+//
+// An empty address, the default, does not serve metrics.
+func SelectMetricsAddress(input string, args []string) string {
+	if argument, found := findArgument(args, "-test.benchy.metrics"); found && argument != "" {
+		return argument
+	}
+
+	return input
+}
@@ -0,0 +1,27 @@
+package params
+
+import (
+	"testing"
+)
+
+func Test_SelectMetricsAddress_FromCLI(t *testing.T) {
+	expected := "localhost:9090"
+	args := []string{"-test.benchy.metrics=" + expected}
+
+	actual := SelectMetricsAddress("localhost:8080", args)
+
+	if actual != expected {
+		t.Errorf("SelectMetricsAddress() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_SelectMetricsAddress_FromInput(t *testing.T) {
+	expected := "localhost:8080"
+	var args []string
+
+	actual := SelectMetricsAddress(expected, args)
+
+	if actual != expected {
+		t.Errorf("SelectMetricsAddress() is %v, want %v", actual, expected)
+	}
+}
@@ -0,0 +1,42 @@
+package params
+
+import (
+	"flag"
+	"strconv"
+)
+
+const profileDirectoryDefault = "./workspace"
+
+var (
+	_ = flag.String("test.benchy.profiledir", "", "Directory that profiles and traces are written to.")
+	_ = flag.Int("test.benchy.keepruns", 0, "Number of profile runs to keep in the profile directory, 0 keeps all runs.")
+)
+
+// SelectProfileDirectory looks for a user-defined profile directory from the
+// input `args` first. Then looks at `input`. Finally, if no profile directory
+// is defined, defaults to "./workspace".
+func SelectProfileDirectory(input string, args []string) string {
+	if argument, found := findArgument(args, "-test.benchy.profiledir"); found && argument != "" {
+		return argument
+	}
+
+	if input == "" {
+		return profileDirectoryDefault
+	}
+
+	return input
+}
+
+// SelectProfileRetention looks for a user-defined number of profile runs to
+// keep from the input `args` first. Then looks at `input`.
+//
+// Zero, the default, keeps all runs.
+func SelectProfileRetention(input int, args []string) int {
+	if argument, found := findArgument(args, "-test.benchy.keepruns"); found {
+		if cliValue, err := strconv.Atoi(argument); err == nil && cliValue >= 0 {
+			return cliValue
+		}
+	}
+
+	return max(0, input)
+}
@@ -0,0 +1,60 @@
+package params
+
+import (
+	"testing"
+)
+
+func Test_SelectProfileDirectory_FromCLI(t *testing.T) {
+	expected := "/tmp/profiles"
+	args := []string{"-test.benchy.profiledir", expected}
+
+	actual := SelectProfileDirectory("./other", args)
+
+	if actual != expected {
+		t.Errorf("SelectProfileDirectory() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_SelectProfileDirectory_FromCLI_WithEquals(t *testing.T) {
+	expected := "/tmp/profiles"
+	args := []string{"-test.benchy.profiledir=" + expected}
+
+	actual := SelectProfileDirectory("", args)
+
+	if actual != expected {
+		t.Errorf("SelectProfileDirectory() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_SelectProfileDirectory_Default(t *testing.T) {
+	expected := profileDirectoryDefault
+	var args []string
+
+	actual := SelectProfileDirectory("", args)
+
+	if actual != expected {
+		t.Errorf("SelectProfileDirectory() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_SelectProfileRetention_FromCLI(t *testing.T) {
+	expected := 3
+	args := []string{"-test.benchy.keepruns", "3"}
+
+	actual := SelectProfileRetention(10, args)
+
+	if actual != expected {
+		t.Errorf("SelectProfileRetention() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_SelectProfileRetention_FromInput(t *testing.T) {
+	expected := 5
+	args := []string{"-test.benchy.keepruns", "many"}
+
+	actual := SelectProfileRetention(expected, args)
+
+	if actual != expected {
+		t.Errorf("SelectProfileRetention() is %v, want %v", actual, expected)
+	}
+}
@@ -0,0 +1,31 @@
+package params
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+)
+
+var _ = flag.Bool("test.benchy.progress", false, "Shows the progress of every benchmark while it is sampled.")
+
+// SelectProgress looks for the user-defined progress flag in the input `args`
+// first, written either as `-test.benchy.progress` or with a value such as
+// `-test.benchy.progress=false`. Then looks at `input`.
+func SelectProgress(input bool, args []string) bool {
+	for _, argument := range args {
+		if strings.EqualFold(argument, "-test.benchy.progress") {
+			return true
+		}
+
+		flagName, flagValue, hasValue := strings.Cut(argument, "=")
+		if !hasValue || !strings.EqualFold(flagName, "-test.benchy.progress") {
+			continue
+		}
+
+		if cliValue, err := strconv.ParseBool(flagValue); err == nil {
+			return cliValue
+		}
+	}
+
+	return input
+}
@@ -0,0 +1,35 @@
+package params
+
+import (
+	"testing"
+)
+
+func Test_SelectProgress_FromCLI(t *testing.T) {
+	args := []string{"-test.benchy.progress", "-test.bench", "."}
+
+	actual := SelectProgress(false, args)
+
+	if !actual {
+		t.Errorf("SelectProgress() is %v, want %v", actual, true)
+	}
+}
+
+func Test_SelectProgress_FromCLI_WithValue(t *testing.T) {
+	args := []string{"-test.benchy.progress=false"}
+
+	actual := SelectProgress(true, args)
+
+	if actual {
+		t.Errorf("SelectProgress() is %v, want %v", actual, false)
+	}
+}
+
+func Test_SelectProgress_FromInput(t *testing.T) {
+	var args []string
+
+	actual := SelectProgress(true, args)
+
+	if !actual {
+		t.Errorf("SelectProgress() is %v, want %v", actual, true)
+	}
+}
@@ -3,7 +3,6 @@ package params
 import (
 	"flag"
 	"strconv"
-	"strings"
 
 	"github.com/smarty/benchy/options"
 )
@@ -21,26 +20,10 @@ var _ = flag.Int("test.samples", 0, "Number of benchmark samples to run and coll
 //
 // Minimum is 1.
 func SelectSampleCount(input int, profile options.BenchmarkProfile, args []string) int {
-	for iArgument, argument := range args {
-		if iArgument == len(args)-1 {
-			break
+	if argument, found := findArgument(args, "-test.samples"); found {
+		if cliValue, err := strconv.Atoi(argument); err == nil && cliValue >= 1 {
+			return cliValue
 		}
-
-		if !strings.EqualFold(argument, "-test.samples") {
-			continue
-		}
-
-		nextArgument := args[iArgument+1]
-		cliValue, err := strconv.Atoi(nextArgument)
-		if err != nil {
-			break
-		}
-
-		if cliValue < 1 {
-			break
-		}
-
-		return cliValue
 	}
 
 	if input == 0 {
@@ -0,0 +1,22 @@
+package params
+
+import (
+	"flag"
+	"strconv"
+)
+
+var _ = flag.Uint64("test.benchy.seed", 0, "Seed of the random providers, 0 picks a random seed which is printed.")
+
+// SelectSeed looks for a user-defined seed for random providers from the input
+// `args` first. Then looks at `input`.
+//
+// Zero, the default, means that a random seed should be picked.
+func SelectSeed(input uint64, args []string) uint64 {
+	if argument, found := findArgument(args, "-test.benchy.seed"); found {
+		if cliValue, err := strconv.ParseUint(argument, 10, 64); err == nil {
+			return cliValue
+		}
+	}
+
+	return input
+}
@@ -0,0 +1,27 @@
+package params
+
+import (
+	"testing"
+)
+
+func Test_SelectSeed_FromCLI(t *testing.T) {
+	expected := uint64(42)
+	args := []string{"-test.benchy.seed", "42"}
+
+	actual := SelectSeed(7, args)
+
+	if actual != expected {
+		t.Errorf("SelectSeed() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_SelectSeed_FromCLI_Invalid(t *testing.T) {
+	expected := uint64(7)
+	args := []string{"-test.benchy.seed=-1"}
+
+	actual := SelectSeed(7, args)
+
+	if actual != expected {
+		t.Errorf("SelectSeed() is %v, want %v", actual, expected)
+	}
+}
@@ -0,0 +1,22 @@
+package params
+
+import (
+	"flag"
+	"strconv"
+)
+
+var _ = flag.Int("test.benchy.width", 0, "Number of columns that plots are fitted to, 0 fits them to the terminal.")
+
+// SelectWidth looks for a user-defined width from the input `args` first. Then
+// looks at `input`.
+//
+// Zero, the default, fits the plots to the terminal.
+func SelectWidth(input int, args []string) int {
+	if argument, found := findArgument(args, "-test.benchy.width"); found {
+		if cliValue, err := strconv.Atoi(argument); err == nil && cliValue >= 0 {
+			return cliValue
+		}
+	}
+
+	return max(0, input)
+}
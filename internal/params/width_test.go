@@ -0,0 +1,27 @@
+package params
+
+import (
+	"testing"
+)
+
+func Test_SelectWidth_FromCLI(t *testing.T) {
+	expected := 120
+	args := []string{"-test.benchy.width=120"}
+
+	actual := SelectWidth(80, args)
+
+	if actual != expected {
+		t.Errorf("SelectWidth() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_SelectWidth_FromInput(t *testing.T) {
+	expected := 80
+	args := []string{"-test.benchy.width", "-1"}
+
+	actual := SelectWidth(expected, args)
+
+	if actual != expected {
+		t.Errorf("SelectWidth() is %v, want %v", actual, expected)
+	}
+}
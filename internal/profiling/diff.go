@@ -0,0 +1,73 @@
+package profiling
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// FunctionDelta is the change in one function's share of a sample value
+// between two profiles.
+type FunctionDelta struct {
+	// Name is the fully qualified function name, as pprof displays it.
+	Name string
+
+	// BasePercent is the flat share of the function in the base profile.
+	BasePercent float64
+
+	// TargetPercent is the flat share of the function in the target profile.
+	TargetPercent float64
+}
+
+// Delta is the change in share from the base to the target, in percentage
+// points.
+func (this FunctionDelta) Delta() float64 {
+	return this.TargetPercent - this.BasePercent
+}
+
+// Diff compares the flat share of every function between `base` and `target`
+// for the sample type named `sampleType`. The results are sorted by the size
+// of the change, largest first.
+func Diff(base *Profile, target *Profile, sampleType string) []FunctionDelta {
+	deltas := make(map[string]*FunctionDelta)
+	delta := func(name string) *FunctionDelta {
+		if existing, found := deltas[name]; found {
+			return existing
+		}
+
+		created := &FunctionDelta{Name: name}
+		deltas[name] = created
+		return created
+	}
+
+	if baseIndex, found := base.ValueIndex(sampleType); found {
+		baseTotal := float64(max(1, base.Total(baseIndex)))
+		for _, functionTotal := range base.FunctionTotals(baseIndex) {
+			delta(functionTotal.Name).BasePercent = float64(functionTotal.Flat) / baseTotal * 100
+		}
+	}
+
+	if targetIndex, found := target.ValueIndex(sampleType); found {
+		targetTotal := float64(max(1, target.Total(targetIndex)))
+		for _, functionTotal := range target.FunctionTotals(targetIndex) {
+			delta(functionTotal.Name).TargetPercent = float64(functionTotal.Flat) / targetTotal * 100
+		}
+	}
+
+	results := make([]FunctionDelta, 0, len(deltas))
+	for _, functionDelta := range deltas {
+		if functionDelta.BasePercent == 0 && functionDelta.TargetPercent == 0 {
+			continue
+		}
+
+		results = append(results, *functionDelta)
+	}
+
+	slices.SortFunc(results, func(left FunctionDelta, right FunctionDelta) int {
+		return cmp.Or(
+			cmp.Compare(math.Abs(right.Delta()), math.Abs(left.Delta())),
+			cmp.Compare(left.Name, right.Name))
+	})
+
+	return results
+}
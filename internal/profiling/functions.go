@@ -0,0 +1,104 @@
+package profiling
+
+import (
+	"cmp"
+	"slices"
+)
+
+// FunctionTotal is the amount of a sample value attributed to one function.
+type FunctionTotal struct {
+	// Name is the fully qualified function name, as pprof displays it.
+	Name string
+
+	// Flat is the value of the samples where this function was the leaf.
+	Flat int64
+
+	// Cumulative is the value of the samples where this function was anywhere
+	// on the stack.
+	Cumulative int64
+}
+
+// ValueIndex finds the index of the sample type named `sampleType`. If the
+// profile has no such sample type, the last sample type is used, which is the
+// convention pprof follows.
+//
+// Returns:
+//   - index is the index of the values of the sample type in every sample.
+//   - found is false when the profile has no sample types at all, in which
+//     case there are no values to sum.
+func (this *Profile) ValueIndex(sampleType string) (index int, found bool) {
+	for i, valueType := range this.SampleTypes {
+		if valueType.Type == sampleType {
+			return i, true
+		}
+	}
+
+	return len(this.SampleTypes) - 1, len(this.SampleTypes) > 0
+}
+
+// Total sums the value at `valueIndex` over all samples.
+func (this *Profile) Total(valueIndex int) (total int64) {
+	for _, sample := range this.Samples {
+		total += sample.Values[valueIndex]
+	}
+
+	return total
+}
+
+// FunctionTotals attributes the value at `valueIndex` of every sample to the
+// functions on its stack. The results are sorted by flat value, then by
+// cumulative value, highest first.
+func (this *Profile) FunctionTotals(valueIndex int) []FunctionTotal {
+	totals := make(map[string]*FunctionTotal)
+	total := func(name string) *FunctionTotal {
+		if existing, found := totals[name]; found {
+			return existing
+		}
+
+		created := &FunctionTotal{Name: name}
+		totals[name] = created
+		return created
+	}
+
+	for _, sample := range this.Samples {
+		value := sample.Values[valueIndex]
+		if value == 0 {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for iLocation, location := range sample.Locations {
+			for iLine, line := range location.Lines {
+				if line.Function == nil {
+					continue
+				}
+
+				name := line.Function.Name
+				// the first line of the first location is the innermost frame
+				if iLocation == 0 && iLine == 0 {
+					total(name).Flat += value
+				}
+
+				// recursive functions only count once per sample
+				if !seen[name] {
+					seen[name] = true
+					total(name).Cumulative += value
+				}
+			}
+		}
+	}
+
+	results := make([]FunctionTotal, 0, len(totals))
+	for _, functionTotal := range totals {
+		results = append(results, *functionTotal)
+	}
+
+	slices.SortFunc(results, func(left FunctionTotal, right FunctionTotal) int {
+		return cmp.Or(
+			cmp.Compare(right.Flat, left.Flat),
+			cmp.Compare(right.Cumulative, left.Cumulative),
+			cmp.Compare(left.Name, right.Name))
+	})
+
+	return results
+}
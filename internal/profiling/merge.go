@@ -0,0 +1,205 @@
+package profiling
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	IncompatibleProfilesError = fmt.Errorf("incompatible profiles")
+)
+
+// Merge combines `profiles` into a single profile. Identical mappings,
+// functions and locations are shared, and samples with the same stack and
+// labels have their values summed. All profiles must have the same sample and
+// period types, and every sample must have a value for each sample type.
+func Merge(profiles ...*Profile) (*Profile, error) {
+	if len(profiles) == 0 {
+		return nil, fmt.Errorf("%w: nothing to merge", IncompatibleProfilesError)
+	}
+
+	first := profiles[0]
+	merged := &Profile{
+		SampleTypes:       slices.Clone(first.SampleTypes),
+		DropFrames:        first.DropFrames,
+		KeepFrames:        first.KeepFrames,
+		PeriodType:        first.PeriodType,
+		Period:            first.Period,
+		DefaultSampleType: first.DefaultSampleType,
+	}
+
+	merger := &merger{
+		profile:   merged,
+		mappings:  make(map[string]*Mapping),
+		functions: make(map[string]*Function),
+		locations: make(map[string]*Location),
+		samples:   make(map[string]*Sample),
+	}
+
+	for iProfile, profile := range profiles {
+		if !slices.Equal(profile.SampleTypes, first.SampleTypes) || profile.PeriodType != first.PeriodType {
+			return nil, fmt.Errorf(
+				"%w: profile %d has sample types %v (period %v), expected %v (period %v)",
+				IncompatibleProfilesError,
+				iProfile,
+				profile.SampleTypes,
+				profile.PeriodType,
+				first.SampleTypes,
+				first.PeriodType)
+		}
+
+		if profile.TimeNanos != 0 && (merged.TimeNanos == 0 || profile.TimeNanos < merged.TimeNanos) {
+			merged.TimeNanos = profile.TimeNanos
+		}
+
+		merged.DurationNanos += profile.DurationNanos
+		for _, comment := range profile.Comments {
+			if !slices.Contains(merged.Comments, comment) {
+				merged.Comments = append(merged.Comments, comment)
+			}
+		}
+
+		for _, sample := range profile.Samples {
+			if len(sample.Values) != len(merged.SampleTypes) {
+				return nil, fmt.Errorf(
+					"%w: a sample of profile %d has %d values, expected %d",
+					IncompatibleProfilesError,
+					iProfile,
+					len(sample.Values),
+					len(merged.SampleTypes))
+			}
+
+			merger.addSample(sample)
+		}
+	}
+
+	return merged, nil
+}
+
+type merger struct {
+	profile   *Profile
+	mappings  map[string]*Mapping
+	functions map[string]*Function
+	locations map[string]*Location
+	samples   map[string]*Sample
+}
+
+func (this *merger) addSample(sample *Sample) {
+	locations := make([]*Location, len(sample.Locations))
+	for i, location := range sample.Locations {
+		locations[i] = this.location(location)
+	}
+
+	labels := slices.Clone(sample.Labels)
+	slices.SortFunc(labels, func(left Label, right Label) int { return strings.Compare(labelKey(left), labelKey(right)) })
+
+	key := strings.Builder{}
+	for _, location := range locations {
+		key.WriteString(strconv.FormatUint(location.ID, 10))
+		key.WriteByte(',')
+	}
+
+	for _, label := range labels {
+		key.WriteString(labelKey(label))
+		key.WriteByte(';')
+	}
+
+	if existing, found := this.samples[key.String()]; found {
+		for i := range existing.Values {
+			existing.Values[i] += sample.Values[i]
+		}
+
+		return
+	}
+
+	merged := &Sample{
+		Locations: locations,
+		Values:    slices.Clone(sample.Values),
+		Labels:    labels,
+	}
+
+	this.samples[key.String()] = merged
+	this.profile.Samples = append(this.profile.Samples, merged)
+}
+
+func (this *merger) location(location *Location) *Location {
+	mapping := this.mapping(location.Mapping)
+	lines := make([]Line, len(location.Lines))
+	for i, line := range location.Lines {
+		lines[i] = Line{Function: this.function(line.Function), Line: line.Line, Column: line.Column}
+	}
+
+	key := strings.Builder{}
+	if mapping != nil {
+		key.WriteString(strconv.FormatUint(mapping.ID, 10))
+	}
+
+	key.WriteString(fmt.Sprintf("|%x|%t", location.Address, location.IsFolded))
+	for _, line := range lines {
+		if line.Function != nil {
+			key.WriteString(fmt.Sprintf("|%d", line.Function.ID))
+		}
+
+		key.WriteString(fmt.Sprintf(":%d:%d", line.Line, line.Column))
+	}
+
+	if existing, found := this.locations[key.String()]; found {
+		return existing
+	}
+
+	merged := &Location{
+		ID:       uint64(len(this.profile.Locations) + 1),
+		Mapping:  mapping,
+		Address:  location.Address,
+		Lines:    lines,
+		IsFolded: location.IsFolded,
+	}
+
+	this.locations[key.String()] = merged
+	this.profile.Locations = append(this.profile.Locations, merged)
+	return merged
+}
+
+func (this *merger) mapping(mapping *Mapping) *Mapping {
+	if mapping == nil {
+		return nil
+	}
+
+	key := fmt.Sprintf("%x|%x|%x|%s|%s", mapping.Start, mapping.Limit, mapping.Offset, mapping.File, mapping.BuildID)
+	if existing, found := this.mappings[key]; found {
+		existing.HasFunctions = existing.HasFunctions && mapping.HasFunctions
+		existing.HasFilenames = existing.HasFilenames && mapping.HasFilenames
+		existing.HasLineNumbers = existing.HasLineNumbers && mapping.HasLineNumbers
+		existing.HasInlineFrames = existing.HasInlineFrames && mapping.HasInlineFrames
+		return existing
+	}
+
+	merged := *mapping
+	merged.ID = uint64(len(this.profile.Mappings) + 1)
+	this.mappings[key] = &merged
+	this.profile.Mappings = append(this.profile.Mappings, &merged)
+	return &merged
+}
+
+func (this *merger) function(function *Function) *Function {
+	if function == nil {
+		return nil
+	}
+
+	key := fmt.Sprintf("%s|%s|%s|%d", function.Name, function.SystemName, function.Filename, function.StartLine)
+	if existing, found := this.functions[key]; found {
+		return existing
+	}
+
+	merged := *function
+	merged.ID = uint64(len(this.profile.Functions) + 1)
+	this.functions[key] = &merged
+	this.profile.Functions = append(this.profile.Functions, &merged)
+	return &merged
+}
+
+func labelKey(label Label) string {
+	return fmt.Sprintf("%s=%s/%d/%s", label.Key, label.Str, label.Num, label.NumUnit)
+}
@@ -0,0 +1,609 @@
+package profiling
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// Profile is a decoded profile.proto message, as written by runtime/pprof. All
+// string table references are resolved and all ID references are replaced by
+// pointers, so that profiles can be compared and merged directly.
+type Profile struct {
+	SampleTypes       []ValueType
+	Samples           []*Sample
+	Mappings          []*Mapping
+	Locations         []*Location
+	Functions         []*Function
+	DropFrames        string
+	KeepFrames        string
+	TimeNanos         int64
+	DurationNanos     int64
+	PeriodType        ValueType
+	Period            int64
+	Comments          []string
+	DefaultSampleType string
+}
+
+// ValueType describes the semantics and unit of a value.
+type ValueType struct {
+	Type string
+	Unit string
+}
+
+// Sample is a single measurement with its call stack, leaf first.
+type Sample struct {
+	Locations []*Location
+	Values    []int64
+	Labels    []Label
+}
+
+// Label is a key/value pair attached to a sample, such as a pprof label.
+type Label struct {
+	Key     string
+	Str     string
+	Num     int64
+	NumUnit string
+}
+
+// Mapping is a memory region that program code was loaded from.
+type Mapping struct {
+	ID              uint64
+	Start           uint64
+	Limit           uint64
+	Offset          uint64
+	File            string
+	BuildID         string
+	HasFunctions    bool
+	HasFilenames    bool
+	HasLineNumbers  bool
+	HasInlineFrames bool
+}
+
+// Location is a unique place in the program, which may expand to several
+// lines when functions are inlined.
+type Location struct {
+	ID       uint64
+	Mapping  *Mapping
+	Address  uint64
+	Lines    []Line
+	IsFolded bool
+}
+
+// Line is a single source line of a location.
+type Line struct {
+	Function *Function
+	Line     int64
+	Column   int64
+}
+
+// Function is a function in the program.
+type Function struct {
+	ID         uint64
+	Name       string
+	SystemName string
+	Filename   string
+	StartLine  int64
+}
+
+// Parse decodes a profile, which may be gzip compressed (as runtime/pprof
+// writes it) or a plain profile.proto message.
+func Parse(data []byte) (*Profile, error) {
+	if len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+
+		if data, err = io.ReadAll(reader); err != nil {
+			return nil, err
+		}
+	}
+
+	decoded := &rawProfile{}
+	if err := decoded.decode(data); err != nil {
+		return nil, err
+	}
+
+	return decoded.resolve()
+}
+
+// WriteTo encodes the profile and writes it gzip compressed, in the same form
+// that runtime/pprof produces. IDs are reassigned in slice order.
+func (this *Profile) WriteTo(writer io.Writer) (count int64, err error) {
+	compressed := &bytes.Buffer{}
+	zipper := gzip.NewWriter(compressed)
+	if _, err = zipper.Write(this.encode()); err != nil {
+		return 0, err
+	}
+
+	if err = zipper.Close(); err != nil {
+		return 0, err
+	}
+
+	return compressed.WriteTo(writer)
+}
+
+func (this *Profile) encode() []byte {
+	strings := newStringTable()
+	for i, mapping := range this.Mappings {
+		mapping.ID = uint64(i + 1)
+	}
+
+	for i, function := range this.Functions {
+		function.ID = uint64(i + 1)
+	}
+
+	for i, location := range this.Locations {
+		location.ID = uint64(i + 1)
+	}
+
+	profile := encoder{}
+	for _, sampleType := range this.SampleTypes {
+		profile.message(1, func(message *encoder) { encodeValueType(message, sampleType, strings) })
+	}
+
+	for _, sample := range this.Samples {
+		profile.message(2, func(message *encoder) {
+			locationIDs := make([]uint64, len(sample.Locations))
+			for i, location := range sample.Locations {
+				locationIDs[i] = location.ID
+			}
+
+			message.packedUint64s(1, locationIDs)
+			message.packedInt64s(2, sample.Values)
+			for _, label := range sample.Labels {
+				message.message(3, func(message *encoder) {
+					message.int64(1, strings.index(label.Key))
+					message.int64(2, strings.index(label.Str))
+					message.int64(3, label.Num)
+					message.int64(4, strings.index(label.NumUnit))
+				})
+			}
+		})
+	}
+
+	for _, mapping := range this.Mappings {
+		profile.message(3, func(message *encoder) {
+			message.uint64(1, mapping.ID)
+			message.uint64(2, mapping.Start)
+			message.uint64(3, mapping.Limit)
+			message.uint64(4, mapping.Offset)
+			message.int64(5, strings.index(mapping.File))
+			message.int64(6, strings.index(mapping.BuildID))
+			message.bool(7, mapping.HasFunctions)
+			message.bool(8, mapping.HasFilenames)
+			message.bool(9, mapping.HasLineNumbers)
+			message.bool(10, mapping.HasInlineFrames)
+		})
+	}
+
+	for _, location := range this.Locations {
+		profile.message(4, func(message *encoder) {
+			message.uint64(1, location.ID)
+			if location.Mapping != nil {
+				message.uint64(2, location.Mapping.ID)
+			}
+
+			message.uint64(3, location.Address)
+			for _, line := range location.Lines {
+				message.message(4, func(message *encoder) {
+					if line.Function != nil {
+						message.uint64(1, line.Function.ID)
+					}
+
+					message.int64(2, line.Line)
+					message.int64(3, line.Column)
+				})
+			}
+
+			message.bool(5, location.IsFolded)
+		})
+	}
+
+	for _, function := range this.Functions {
+		profile.message(5, func(message *encoder) {
+			message.uint64(1, function.ID)
+			message.int64(2, strings.index(function.Name))
+			message.int64(3, strings.index(function.SystemName))
+			message.int64(4, strings.index(function.Filename))
+			message.int64(5, function.StartLine)
+		})
+	}
+
+	profile.int64(7, strings.index(this.DropFrames))
+	profile.int64(8, strings.index(this.KeepFrames))
+	profile.int64(9, this.TimeNanos)
+	profile.int64(10, this.DurationNanos)
+	profile.message(11, func(message *encoder) { encodeValueType(message, this.PeriodType, strings) })
+	profile.int64(12, this.Period)
+	for _, comment := range this.Comments {
+		profile.int64(13, strings.index(comment))
+	}
+
+	profile.int64(14, strings.index(this.DefaultSampleType))
+
+	// the string table is written last since every other message adds to it
+	for _, value := range strings.values {
+		profile.bytes(6, []byte(value))
+	}
+
+	return profile.buffer
+}
+
+func encodeValueType(message *encoder, valueType ValueType, strings *stringTable) {
+	message.int64(1, strings.index(valueType.Type))
+	message.int64(2, strings.index(valueType.Unit))
+}
+
+// ----- string table -----
+
+type stringTable struct {
+	values  []string
+	indexes map[string]int64
+}
+
+func newStringTable() *stringTable {
+	// by definition, the first entry of the string table is always ""
+	return &stringTable{
+		values:  []string{""},
+		indexes: map[string]int64{"": 0},
+	}
+}
+
+func (this *stringTable) index(value string) int64 {
+	if index, found := this.indexes[value]; found {
+		return index
+	}
+
+	index := int64(len(this.values))
+	this.values = append(this.values, value)
+	this.indexes[value] = index
+	return index
+}
+
+// ----- decoding -----
+
+// rawProfile holds a profile exactly as it was decoded, with string table
+// indexes and IDs which are resolved once the whole message has been read.
+type rawProfile struct {
+	sampleTypes       [][2]int64
+	samples           []rawSample
+	mappings          []rawMapping
+	locations         []rawLocation
+	functions         []rawFunction
+	stringTable       []string
+	dropFrames        int64
+	keepFrames        int64
+	timeNanos         int64
+	durationNanos     int64
+	periodType        [2]int64
+	period            int64
+	comments          []int64
+	defaultSampleType int64
+}
+
+type rawSample struct {
+	locationIDs []uint64
+	values      []uint64
+	labels      [][4]int64
+}
+
+type rawMapping struct {
+	mapping       Mapping
+	file, buildID int64
+}
+
+type rawLocation struct {
+	id, mappingID, address uint64
+	lines                  [][3]int64
+	isFolded               bool
+}
+
+type rawFunction struct {
+	id                         uint64
+	name, systemName, filename int64
+	startLine                  int64
+}
+
+func (this *rawProfile) decode(data []byte) error {
+	return readFields(data, func(current field) (err error) {
+		switch current.number {
+		case 1:
+			valueType, err := decodeValueType(current.data)
+			this.sampleTypes = append(this.sampleTypes, valueType)
+			return err
+
+		case 2:
+			sample, err := decodeSample(current.data)
+			this.samples = append(this.samples, sample)
+			return err
+
+		case 3:
+			mapping, err := decodeMapping(current.data)
+			this.mappings = append(this.mappings, mapping)
+			return err
+
+		case 4:
+			location, err := decodeLocation(current.data)
+			this.locations = append(this.locations, location)
+			return err
+
+		case 5:
+			function, err := decodeFunction(current.data)
+			this.functions = append(this.functions, function)
+			return err
+
+		case 6:
+			this.stringTable = append(this.stringTable, string(current.data))
+
+		case 7:
+			this.dropFrames = int64(current.value)
+
+		case 8:
+			this.keepFrames = int64(current.value)
+
+		case 9:
+			this.timeNanos = int64(current.value)
+
+		case 10:
+			this.durationNanos = int64(current.value)
+
+		case 11:
+			this.periodType, err = decodeValueType(current.data)
+			return err
+
+		case 12:
+			this.period = int64(current.value)
+
+		case 13:
+			var comments []uint64
+			comments, err = appendUint64s(comments, current)
+			for _, comment := range comments {
+				this.comments = append(this.comments, int64(comment))
+			}
+
+			return err
+
+		case 14:
+			this.defaultSampleType = int64(current.value)
+		}
+
+		return nil
+	})
+}
+
+func decodeValueType(data []byte) (valueType [2]int64, err error) {
+	err = readFields(data, func(current field) error {
+		if current.number == 1 || current.number == 2 {
+			valueType[current.number-1] = int64(current.value)
+		}
+
+		return nil
+	})
+
+	return valueType, err
+}
+
+func decodeSample(data []byte) (sample rawSample, err error) {
+	err = readFields(data, func(current field) (err error) {
+		switch current.number {
+		case 1:
+			sample.locationIDs, err = appendUint64s(sample.locationIDs, current)
+
+		case 2:
+			sample.values, err = appendUint64s(sample.values, current)
+
+		case 3:
+			var label [4]int64
+			err = readFields(current.data, func(current field) error {
+				if current.number >= 1 && current.number <= 4 {
+					label[current.number-1] = int64(current.value)
+				}
+
+				return nil
+			})
+
+			sample.labels = append(sample.labels, label)
+		}
+
+		return err
+	})
+
+	return sample, err
+}
+
+func decodeMapping(data []byte) (mapping rawMapping, err error) {
+	err = readFields(data, func(current field) error {
+		switch current.number {
+		case 1:
+			mapping.mapping.ID = current.value
+		case 2:
+			mapping.mapping.Start = current.value
+		case 3:
+			mapping.mapping.Limit = current.value
+		case 4:
+			mapping.mapping.Offset = current.value
+		case 5:
+			mapping.file = int64(current.value)
+		case 6:
+			mapping.buildID = int64(current.value)
+		case 7:
+			mapping.mapping.HasFunctions = current.value != 0
+		case 8:
+			mapping.mapping.HasFilenames = current.value != 0
+		case 9:
+			mapping.mapping.HasLineNumbers = current.value != 0
+		case 10:
+			mapping.mapping.HasInlineFrames = current.value != 0
+		}
+
+		return nil
+	})
+
+	return mapping, err
+}
+
+func decodeLocation(data []byte) (location rawLocation, err error) {
+	err = readFields(data, func(current field) error {
+		switch current.number {
+		case 1:
+			location.id = current.value
+		case 2:
+			location.mappingID = current.value
+		case 3:
+			location.address = current.value
+		case 4:
+			var line [3]int64
+			err := readFields(current.data, func(current field) error {
+				if current.number >= 1 && current.number <= 3 {
+					line[current.number-1] = int64(current.value)
+				}
+
+				return nil
+			})
+
+			location.lines = append(location.lines, line)
+			return err
+		case 5:
+			location.isFolded = current.value != 0
+		}
+
+		return nil
+	})
+
+	return location, err
+}
+
+func decodeFunction(data []byte) (function rawFunction, err error) {
+	err = readFields(data, func(current field) error {
+		switch current.number {
+		case 1:
+			function.id = current.value
+		case 2:
+			function.name = int64(current.value)
+		case 3:
+			function.systemName = int64(current.value)
+		case 4:
+			function.filename = int64(current.value)
+		case 5:
+			function.startLine = int64(current.value)
+		}
+
+		return nil
+	})
+
+	return function, err
+}
+
+func (this *rawProfile) resolve() (*Profile, error) {
+	var err error
+	str := func(index int64) string {
+		if index < 0 || index >= int64(len(this.stringTable)) {
+			err = fmt.Errorf("%w: string index %d out of range", MalformedProfileError, index)
+			return ""
+		}
+
+		return this.stringTable[index]
+	}
+
+	valueType := func(raw [2]int64) ValueType {
+		return ValueType{Type: str(raw[0]), Unit: str(raw[1])}
+	}
+
+	profile := &Profile{
+		DropFrames:        str(this.dropFrames),
+		KeepFrames:        str(this.keepFrames),
+		TimeNanos:         this.timeNanos,
+		DurationNanos:     this.durationNanos,
+		PeriodType:        valueType(this.periodType),
+		Period:            this.period,
+		DefaultSampleType: str(this.defaultSampleType),
+	}
+
+	for _, sampleType := range this.sampleTypes {
+		profile.SampleTypes = append(profile.SampleTypes, valueType(sampleType))
+	}
+
+	for _, comment := range this.comments {
+		profile.Comments = append(profile.Comments, str(comment))
+	}
+
+	mappings := make(map[uint64]*Mapping, len(this.mappings))
+	for _, raw := range this.mappings {
+		mapping := raw.mapping
+		mapping.File = str(raw.file)
+		mapping.BuildID = str(raw.buildID)
+		mappings[mapping.ID] = &mapping
+		profile.Mappings = append(profile.Mappings, &mapping)
+	}
+
+	functions := make(map[uint64]*Function, len(this.functions))
+	for _, raw := range this.functions {
+		function := &Function{
+			ID:         raw.id,
+			Name:       str(raw.name),
+			SystemName: str(raw.systemName),
+			Filename:   str(raw.filename),
+			StartLine:  raw.startLine,
+		}
+
+		functions[function.ID] = function
+		profile.Functions = append(profile.Functions, function)
+	}
+
+	locations := make(map[uint64]*Location, len(this.locations))
+	for _, raw := range this.locations {
+		location := &Location{
+			ID:       raw.id,
+			Mapping:  mappings[raw.mappingID],
+			Address:  raw.address,
+			IsFolded: raw.isFolded,
+		}
+
+		for _, line := range raw.lines {
+			location.Lines = append(location.Lines, Line{
+				Function: functions[uint64(line[0])],
+				Line:     line[1],
+				Column:   line[2],
+			})
+		}
+
+		locations[location.ID] = location
+		profile.Locations = append(profile.Locations, location)
+	}
+
+	for _, raw := range this.samples {
+		sample := &Sample{
+			Locations: make([]*Location, 0, len(raw.locationIDs)),
+			Values:    make([]int64, 0, len(raw.values)),
+		}
+
+		for _, id := range raw.locationIDs {
+			location, found := locations[id]
+			if !found {
+				return nil, fmt.Errorf("%w: sample references unknown location %d", MalformedProfileError, id)
+			}
+
+			sample.Locations = append(sample.Locations, location)
+		}
+
+		for _, value := range raw.values {
+			sample.Values = append(sample.Values, int64(value))
+		}
+
+		for _, label := range raw.labels {
+			sample.Labels = append(sample.Labels, Label{
+				Key:     str(label[0]),
+				Str:     str(label[1]),
+				Num:     label[2],
+				NumUnit: str(label[3]),
+			})
+		}
+
+		profile.Samples = append(profile.Samples, sample)
+	}
+
+	return profile, err
+}
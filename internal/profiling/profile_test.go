@@ -0,0 +1,170 @@
+package profiling
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"runtime/pprof"
+	"testing"
+	"time"
+)
+
+func TestProfile_ParseRuntimeProfile(t *testing.T) {
+	profile, err := Parse(recordCPUProfile(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ValueType{{Type: "samples", Unit: "count"}, {Type: "cpu", Unit: "nanoseconds"}}
+	if !reflect.DeepEqual(profile.SampleTypes, expected) {
+		t.Errorf("expected sample types %v, got %v", expected, profile.SampleTypes)
+	}
+
+	if profile.Period == 0 {
+		t.Error("expected a non-zero period")
+	}
+}
+
+func TestProfile_WriteAndParse(t *testing.T) {
+	var err error
+	buffer := &bytes.Buffer{}
+	expected := newTestProfile()
+	if _, err = expected.WriteTo(buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := Parse(buffer.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %+v, actual: %+v", expected, actual)
+	}
+}
+
+func TestMerge_SumsMatchingSamples(t *testing.T) {
+	merged, err := Merge(newTestProfile(), newTestProfile())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(merged.Samples) != 2 || len(merged.Locations) != 2 || len(merged.Functions) != 2 || len(merged.Mappings) != 1 {
+		t.Fatalf("expected shared stacks, got %d samples, %d locations, %d functions, %d mappings",
+			len(merged.Samples), len(merged.Locations), len(merged.Functions), len(merged.Mappings))
+	}
+
+	if !reflect.DeepEqual(merged.Samples[0].Values, []int64{2, 20}) {
+		t.Errorf("expected summed values [2 20], got %v", merged.Samples[0].Values)
+	}
+
+	if merged.DurationNanos != 2_000 {
+		t.Errorf("expected summed duration 2000, got %d", merged.DurationNanos)
+	}
+}
+
+func TestMerge_RejectsIncompatibleProfiles(t *testing.T) {
+	other := newTestProfile()
+	other.SampleTypes = other.SampleTypes[:1]
+
+	if _, err := Merge(newTestProfile(), other); err == nil {
+		t.Error("expected an error when merging profiles with different sample types")
+	}
+}
+
+func TestMerge_RejectsSamplesWithMissingValues(t *testing.T) {
+	other := newTestProfile()
+	other.Samples[0].Values = other.Samples[0].Values[:1]
+
+	_, err := Merge(newTestProfile(), other)
+	if !errors.Is(err, IncompatibleProfilesError) {
+		t.Errorf("expected an incompatible profiles error, got %v", err)
+	}
+}
+
+func TestProfile_FunctionTotals(t *testing.T) {
+	profile := newTestProfile()
+
+	valueIndex, _ := profile.ValueIndex("cpu")
+	actual := profile.FunctionTotals(valueIndex)
+
+	expected := []FunctionTotal{
+		{Name: "main.caller", Flat: 30, Cumulative: 40},
+		{Name: "main.callee", Flat: 10, Cumulative: 10},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %+v, actual: %+v", expected, actual)
+	}
+}
+
+func TestDiff_OrdersByLargestChange(t *testing.T) {
+	base := newTestProfile()
+	target := newTestProfile()
+	target.Samples[0].Values = []int64{3, 30}
+
+	actual := Diff(base, target, "cpu")
+
+	expected := []FunctionDelta{
+		{Name: "main.callee", BasePercent: 25, TargetPercent: 50},
+		{Name: "main.caller", BasePercent: 75, TargetPercent: 50},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %+v, actual: %+v", expected, actual)
+	}
+}
+
+func TestProfile_ValueIndexWithoutSampleTypes(t *testing.T) {
+	if _, found := (&Profile{}).ValueIndex("cpu"); found {
+		t.Error("expected no value index for a profile without sample types")
+	}
+}
+
+func TestDiff_SkipsProfilesWithoutSampleTypes(t *testing.T) {
+	actual := Diff(&Profile{}, newTestProfile(), "cpu")
+
+	expected := []FunctionDelta{
+		{Name: "main.caller", TargetPercent: 75},
+		{Name: "main.callee", TargetPercent: 25},
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %+v, actual: %+v", expected, actual)
+	}
+}
+
+func recordCPUProfile(t *testing.T) []byte {
+	buffer := &bytes.Buffer{}
+	if err := pprof.StartCPUProfile(buffer); err != nil {
+		t.Skip("cpu profiling is not available:", err)
+	}
+
+	spin := 0
+	for deadline := time.Now().Add(50 * time.Millisecond); time.Now().Before(deadline); {
+		spin++
+	}
+
+	pprof.StopCPUProfile()
+	return buffer.Bytes()
+}
+
+func newTestProfile() *Profile {
+	mapping := &Mapping{ID: 1, Start: 0x1000, Limit: 0x2000, File: "/bin/test", HasFunctions: true}
+	caller := &Function{ID: 1, Name: "main.caller", SystemName: "main.caller", Filename: "main.go", StartLine: 3}
+	callee := &Function{ID: 2, Name: "main.callee", SystemName: "main.callee", Filename: "main.go", StartLine: 9}
+	callerLocation := &Location{ID: 1, Mapping: mapping, Address: 0x1010, Lines: []Line{{Function: caller, Line: 5}}}
+	calleeLocation := &Location{ID: 2, Mapping: mapping, Address: 0x1020, Lines: []Line{{Function: callee, Line: 10}}}
+
+	return &Profile{
+		SampleTypes: []ValueType{{Type: "samples", Unit: "count"}, {Type: "cpu", Unit: "nanoseconds"}},
+		Samples: []*Sample{
+			{Locations: []*Location{calleeLocation, callerLocation}, Values: []int64{1, 10}},
+			{Locations: []*Location{callerLocation}, Values: []int64{3, 30}, Labels: []Label{{Key: "phase", Str: "setup"}}},
+		},
+		Mappings:      []*Mapping{mapping},
+		Locations:     []*Location{callerLocation, calleeLocation},
+		Functions:     []*Function{caller, callee},
+		TimeNanos:     42,
+		DurationNanos: 1_000,
+		PeriodType:    ValueType{Type: "cpu", Unit: "nanoseconds"},
+		Period:        10_000_000,
+	}
+}
@@ -0,0 +1,170 @@
+package profiling
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var (
+	MalformedProfileError = fmt.Errorf("malformed profile")
+)
+
+// field is a single decoded protobuf field. Depending on the wire type, either
+// `value` (varint and fixed types) or `data` (length-delimited types) is set.
+type field struct {
+	number   int
+	wireType int
+	value    uint64
+	data     []byte
+}
+
+// readFields walks every field in the protobuf message `data` in order and
+// calls `visit` for each one.
+func readFields(data []byte, visit func(field) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return fmt.Errorf("%w: bad field key", MalformedProfileError)
+		}
+
+		data = data[n:]
+		current := field{number: int(key >> 3), wireType: int(key & 7)}
+		switch current.wireType {
+		case wireVarint:
+			current.value, n = binary.Uvarint(data)
+			if n <= 0 {
+				return fmt.Errorf("%w: bad varint in field %d", MalformedProfileError, current.number)
+			}
+
+			data = data[n:]
+
+		case wireFixed64:
+			if len(data) < 8 {
+				return fmt.Errorf("%w: short fixed64 in field %d", MalformedProfileError, current.number)
+			}
+
+			current.value = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return fmt.Errorf("%w: bad length in field %d", MalformedProfileError, current.number)
+			}
+
+			current.data = data[n : n+int(length)]
+			data = data[n+int(length):]
+
+		case wireFixed32:
+			if len(data) < 4 {
+				return fmt.Errorf("%w: short fixed32 in field %d", MalformedProfileError, current.number)
+			}
+
+			current.value = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+
+		default:
+			return fmt.Errorf("%w: unsupported wire type %d", MalformedProfileError, current.wireType)
+		}
+
+		if err := visit(current); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appendUint64s decodes a repeated integer field, which may be either packed
+// or written one value at a time.
+func appendUint64s(values []uint64, current field) ([]uint64, error) {
+	if current.wireType != wireBytes {
+		return append(values, current.value), nil
+	}
+
+	data := current.data
+	for len(data) > 0 {
+		value, n := binary.Uvarint(data)
+		if n <= 0 {
+			return values, fmt.Errorf("%w: bad packed varint in field %d", MalformedProfileError, current.number)
+		}
+
+		values = append(values, value)
+		data = data[n:]
+	}
+
+	return values, nil
+}
+
+// encoder writes protobuf fields. Zero values are omitted, as in proto3.
+type encoder struct {
+	buffer []byte
+}
+
+func (this *encoder) key(number int, wireType int) {
+	this.buffer = binary.AppendUvarint(this.buffer, uint64(number)<<3|uint64(wireType))
+}
+
+func (this *encoder) uint64(number int, value uint64) {
+	if value == 0 {
+		return
+	}
+
+	this.key(number, wireVarint)
+	this.buffer = binary.AppendUvarint(this.buffer, value)
+}
+
+func (this *encoder) int64(number int, value int64) {
+	this.uint64(number, uint64(value))
+}
+
+func (this *encoder) bool(number int, value bool) {
+	if value {
+		this.uint64(number, 1)
+	}
+}
+
+func (this *encoder) bytes(number int, value []byte) {
+	this.key(number, wireBytes)
+	this.buffer = binary.AppendUvarint(this.buffer, uint64(len(value)))
+	this.buffer = append(this.buffer, value...)
+}
+
+func (this *encoder) packedUint64s(number int, values []uint64) {
+	if len(values) == 0 {
+		return
+	}
+
+	packed := make([]byte, 0, len(values)*2)
+	for _, value := range values {
+		packed = binary.AppendUvarint(packed, value)
+	}
+
+	this.bytes(number, packed)
+}
+
+func (this *encoder) packedInt64s(number int, values []int64) {
+	if len(values) == 0 {
+		return
+	}
+
+	packed := make([]byte, 0, len(values)*2)
+	for _, value := range values {
+		packed = binary.AppendUvarint(packed, uint64(value))
+	}
+
+	this.bytes(number, packed)
+}
+
+func (this *encoder) message(number int, write func(*encoder)) {
+	nested := encoder{}
+	write(&nested)
+	this.bytes(number, nested.buffer)
+}
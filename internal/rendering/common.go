@@ -1,16 +1,31 @@
 package rendering
 
-const (
-	ansi_red     = "\033[91m"
-	ansi_green   = "\033[92m"
-	ansi_yellow  = "\033[93m"
-	ansi_blue    = "\033[94m"
-	ansi_magenta = "\033[95m"
-	ansi_cyan    = "\033[96m"
-	ansi_white   = "\033[97m"
-	ansi_reset   = "\033[0m"
+import (
+	"github.com/smarty/benchy/options"
 )
 
+const ansi_reset = "\033[0m"
+
+// paint surrounds `text` with `color` and a reset. Text is returned as it is
+// when there is no color, so that nothing but the text is written.
+func paint(color options.Color, text string) string {
+	if color == "" || text == "" {
+		return text
+	}
+
+	return string(color) + text + ansi_reset
+}
+
+// highlight colors `text` inside a line painted with `resume`, which is
+// restored after the text.
+func highlight(color options.Color, text string, resume options.Color) string {
+	if color == "" || text == "" {
+		return text
+	}
+
+	return string(color) + text + ansi_reset + string(resume)
+}
+
 func padRight(value string, totalLength int, paddingRune rune) string {
 	padding := make([]rune, max(0, totalLength-stringLength(value)))
 	if len(padding) == 0 {
@@ -0,0 +1,179 @@
+package rendering
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/smarty/benchy/options"
+	"github.com/smarty/benchy/stats"
+)
+
+// unchangedThreshold is the smallest delta, in percent, that is shown as
+// better or worse than the baseline.
+const unchangedThreshold = 0.05
+
+// comparison relates the results in the report card to a baseline result.
+type comparison struct {
+	baseline *stats.BenchmarkResult
+	theme    options.Theme
+}
+
+// rankResults sorts a copy of `results` by `statistic`, lowest first. Results
+// with the same value keep their order.
+func rankResults(results []*stats.BenchmarkResult, sampleCount int, statistic options.Statistic) []*stats.BenchmarkResult {
+	getField := statisticField(statistic, sampleCount)
+	ranked := slices.Clone(results)
+	slices.SortStableFunc(ranked, func(left *stats.BenchmarkResult, right *stats.BenchmarkResult) int {
+		return cmp.Compare(getField(left), getField(right))
+	})
+
+	return ranked
+}
+
+// findBaseline finds the result named `name`, or the result with the lowest
+// average when `fastest` is set. Returns nil if there is no baseline.
+func findBaseline(results []*stats.BenchmarkResult, name string, fastest bool) *stats.BenchmarkResult {
+	var baseline *stats.BenchmarkResult
+	for _, result := range results {
+		if fastest && (baseline == nil || result.Average < baseline.Average) {
+			baseline = result
+		}
+
+		if !fastest && name != "" && strings.EqualFold(result.Name, name) {
+			return result
+		}
+	}
+
+	return baseline
+}
+
+func statisticField(statistic options.Statistic, sampleCount int) func(result *stats.BenchmarkResult) stats.Duration {
+	if sampleCount < stats.MinFullCalculation {
+		statistic = options.Average
+	}
+
+	switch statistic {
+	case options.Median:
+		return func(result *stats.BenchmarkResult) stats.Duration { return result.Median }
+
+	case options.Min:
+		return func(result *stats.BenchmarkResult) stats.Duration { return result.Min }
+
+	case options.Max:
+		return func(result *stats.BenchmarkResult) stats.Duration { return result.Max }
+
+	default:
+		return func(result *stats.BenchmarkResult) stats.Duration { return result.Average }
+	}
+}
+
+// addRelativeColumn adds how many times slower or faster the average of every
+// result is compared to the baseline, for example "2.35x slower".
+func addRelativeColumn(data *[][]string, results []*stats.BenchmarkResult, comparison comparison) {
+	relatives := make([]string, len(results))
+	length := stringLength("RELATIVE")
+	for iResult, result := range results {
+		relatives[iResult] = renderRelative(result.Average, comparison.baseline.Average)
+		length = max(length, stringLength(relatives[iResult]))
+	}
+
+	// add two lines for the table header
+	column := make([]string, len(results)+2)
+	column[0] = padLeft("RELATIVE", length, ' ')
+	column[1] = padLeft("", length, '-')
+	for iResult, result := range results {
+		color := comparison.color(result.Average, comparison.baseline.Average)
+		column[iResult+2] = highlight(color, padLeft(relatives[iResult], length, ' '), comparison.theme.Table)
+	}
+
+	*data = append(*data, column)
+}
+
+// addComparedColumn adds a duration column like addColumn, followed by the
+// change of every value from the value of the baseline, in percent.
+func addComparedColumn(data *[][]string, columnName string, results []*stats.BenchmarkResult, comparison comparison, getField func(result *stats.BenchmarkResult) stats.Duration) {
+	if comparison.baseline == nil {
+		addColumn(data, columnName, results, getField)
+		return
+	}
+
+	baselineValue := getField(comparison.baseline)
+	deltas := make([]string, len(results))
+	deltaLength := 0
+	for iResult, result := range results {
+		if result != comparison.baseline {
+			deltas[iResult] = renderDelta(getField(result), baselineValue)
+		}
+
+		deltaLength = max(deltaLength, stringLength(deltas[iResult]))
+	}
+
+	valueLength, unit := calculateRecommendedReportItemLength("", results, getField)
+	length := max(stringLength(columnName), valueLength+1+deltaLength)
+	valueLength = length - 1 - deltaLength
+
+	// add two lines for the table header
+	column := make([]string, len(results)+2)
+	column[0] = padLeft(columnName, length, ' ')
+	column[1] = padLeft("", length, '-')
+	for iResult, result := range results {
+		value := getField(result)
+		color := comparison.color(value, baselineValue)
+		column[iResult+2] = fmt.Sprintf(
+			"%s %s",
+			padLeft(value.RenderWithUnit(unit), valueLength, ' '),
+			highlight(color, padLeft(deltas[iResult], deltaLength, ' '), comparison.theme.Table))
+	}
+
+	*data = append(*data, column)
+}
+
+// color selects the theme color for a duration compared to the baseline, where
+// lower is better.
+func (this comparison) color(value stats.Duration, baselineValue stats.Duration) options.Color {
+	if baselineValue == 0 {
+		return ""
+	}
+
+	delta := float64((value - baselineValue) / baselineValue * 100)
+	switch {
+	case delta <= -unchangedThreshold:
+		return this.theme.Better
+
+	case delta >= unchangedThreshold:
+		return this.theme.Worse
+
+	default:
+		return ""
+	}
+}
+
+func renderRelative(value stats.Duration, baselineValue stats.Duration) string {
+	if value <= 0 || baselineValue <= 0 {
+		return "-"
+	}
+
+	slower := fmt.Sprintf("%0.2f", float64(value/baselineValue))
+	faster := fmt.Sprintf("%0.2f", float64(baselineValue/value))
+	switch {
+	case value > baselineValue && slower != "1.00":
+		return slower + "x slower"
+
+	case value < baselineValue && faster != "1.00":
+		return faster + "x faster"
+
+	default:
+		return "1.00x"
+	}
+}
+
+func renderDelta(value stats.Duration, baselineValue stats.Duration) string {
+	if baselineValue == 0 || math.IsNaN(float64(value)) {
+		return "-"
+	}
+
+	return fmt.Sprintf("%+0.1f%%", float64((value-baselineValue)/baselineValue*100))
+}
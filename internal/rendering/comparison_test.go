@@ -0,0 +1,72 @@
+package rendering
+
+import (
+	"testing"
+
+	"github.com/smarty/benchy/options"
+	"github.com/smarty/benchy/stats"
+)
+
+func Test_renderRelative(t *testing.T) {
+	cases := []struct {
+		value    stats.Duration
+		baseline stats.Duration
+		expected string
+	}{
+		{value: 100, baseline: 100, expected: "1.00x"},
+		{value: 235, baseline: 100, expected: "2.35x slower"},
+		{value: 50, baseline: 100, expected: "2.00x faster"},
+		{value: 100.1, baseline: 100, expected: "1.00x"},
+		{value: 100, baseline: 0, expected: "-"},
+	}
+
+	for _, c := range cases {
+		actual := renderRelative(c.value, c.baseline)
+
+		if actual != c.expected {
+			t.Errorf("renderRelative(%v, %v) is %v, want %v", c.value, c.baseline, actual, c.expected)
+		}
+	}
+}
+
+func Test_renderDelta(t *testing.T) {
+	expected := "-25.0%"
+
+	actual := renderDelta(75, 100)
+
+	if actual != expected {
+		t.Errorf("renderDelta() is %v, want %v", actual, expected)
+	}
+}
+
+func Test_rankResults(t *testing.T) {
+	slow := &stats.BenchmarkResult{Name: "slow", Average: 3, Median: 1}
+	fast := &stats.BenchmarkResult{Name: "fast", Average: 1, Median: 2}
+	results := []*stats.BenchmarkResult{slow, fast}
+
+	byAverage := rankResults(results, stats.MinFullCalculation, options.Average)
+	byMedian := rankResults(results, stats.MinFullCalculation, options.Median)
+
+	if byAverage[0] != fast || byAverage[1] != slow {
+		t.Errorf("ranking by average is [%s %s], want [fast slow]", byAverage[0].Name, byAverage[1].Name)
+	}
+
+	if byMedian[0] != slow || byMedian[1] != fast {
+		t.Errorf("ranking by median is [%s %s], want [slow fast]", byMedian[0].Name, byMedian[1].Name)
+	}
+
+	if results[0] != slow {
+		t.Error("ranking changed the order of the input")
+	}
+}
+
+func Test_findBaseline_Fastest(t *testing.T) {
+	slow := &stats.BenchmarkResult{Name: "slow", Average: 3}
+	fast := &stats.BenchmarkResult{Name: "fast", Average: 1}
+
+	actual := findBaseline([]*stats.BenchmarkResult{slow, fast}, "slow", true)
+
+	if actual != fast {
+		t.Errorf("findBaseline() is %v, want fast", actual)
+	}
+}
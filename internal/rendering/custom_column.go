@@ -0,0 +1,115 @@
+package rendering
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/smarty/benchy/options"
+	"github.com/smarty/benchy/stats"
+)
+
+// throughputSuffix follows throughputs of custom columns, which are not known
+// to be bytes or items.
+const throughputSuffix = "/s"
+
+// CustomColumn is a report card column defined outside Benchy.
+type CustomColumn struct {
+	// Title is the header of the column.
+	Title string
+
+	// Value gets the value to show for a result.
+	Value func(result *stats.BenchmarkResult) any
+
+	// Format describes how the values are rendered.
+	Format options.ColumnFormat
+}
+
+func addCustomColumn(data *[][]string, results []*stats.BenchmarkResult, sampleCount int, customColumn CustomColumn) {
+	if sampleCount < customColumn.Format.MinSampleCount {
+		return
+	}
+
+	values := make([]any, len(results))
+	allZero := true
+	for iResult, result := range results {
+		values[iResult] = customColumn.Value(result)
+		allZero = allZero && isZero(values[iResult])
+	}
+
+	if customColumn.Format.HideWhenZero && allZero {
+		return
+	}
+
+	rendered := renderCustomValues(values, customColumn.Format.Format)
+	length := stringLength(customColumn.Title)
+	for _, value := range rendered {
+		length = max(length, stringLength(value))
+	}
+
+	pad := padLeft
+	if customColumn.Format.Alignment == options.AlignLeft {
+		pad = padRight
+	}
+
+	// add two lines for the table header
+	column := make([]string, len(results)+2)
+	column[0] = pad(customColumn.Title, length, ' ')
+	column[1] = pad("", length, '-')
+	for iValue, value := range rendered {
+		column[iValue+2] = pad(value, length, ' ')
+	}
+
+	*data = append(*data, column)
+}
+
+func renderCustomValues(values []any, format func(value any) string) []string {
+	rendered := make([]string, len(values))
+	if format != nil {
+		for iValue, value := range values {
+			rendered[iValue] = format(value)
+		}
+
+		return rendered
+	}
+
+	durationUnits := make([]string, 0, len(values))
+	throughputUnits := make([]string, 0, len(values))
+	for _, value := range values {
+		switch typed := value.(type) {
+		case stats.Duration:
+			durationUnits = append(durationUnits, typed.Unit())
+
+		case stats.Throughput:
+			if typed > 0 {
+				throughputUnits = append(throughputUnits, typed.Unit())
+			}
+		}
+	}
+
+	durationUnit := stats.SmallestUnit(durationUnits...)
+	throughputUnit := stats.SmallestThroughputUnit(throughputUnits...)
+	for iValue, value := range values {
+		switch typed := value.(type) {
+		case nil:
+			rendered[iValue] = "-"
+
+		case stats.Duration:
+			rendered[iValue] = typed.RenderWithUnit(durationUnit)
+
+		case stats.Throughput:
+			rendered[iValue] = typed.RenderWithUnit(throughputUnit, throughputSuffix)
+
+		case float32, float64:
+			rendered[iValue] = fmt.Sprintf("%0.3f", typed)
+
+		default:
+			rendered[iValue] = fmt.Sprint(typed)
+		}
+	}
+
+	return rendered
+}
+
+func isZero(value any) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
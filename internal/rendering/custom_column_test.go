@@ -0,0 +1,60 @@
+package rendering
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/smarty/benchy/options"
+	"github.com/smarty/benchy/stats"
+)
+
+func Test_renderCustomValues_SharesUnits(t *testing.T) {
+	values := []any{stats.Duration(1_500), stats.Duration(250), nil, 0.5, 3}
+
+	actual := renderCustomValues(values, nil)
+
+	expected := []string{" 1.500 µs", " 0.250 µs", "-", "0.500", "3"}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func Test_addCustomColumn_Visibility(t *testing.T) {
+	results := []*stats.BenchmarkResult{{Name: "first"}, {Name: "second"}}
+	zero := func(result *stats.BenchmarkResult) any { return 0.0 }
+	cases := []struct {
+		sampleCount int
+		format      options.ColumnFormat
+		expected    int
+	}{
+		{sampleCount: 3, format: options.ColumnFormat{}, expected: 1},
+		{sampleCount: 3, format: options.ColumnFormat{MinSampleCount: 10}, expected: 0},
+		{sampleCount: 3, format: options.ColumnFormat{HideWhenZero: true}, expected: 0},
+	}
+
+	for _, c := range cases {
+		data := make([][]string, 0)
+
+		addCustomColumn(&data, results, c.sampleCount, CustomColumn{Title: "ZERO", Value: zero, Format: c.format})
+
+		if len(data) != c.expected {
+			t.Errorf("with %+v, %d columns were added, want %d", c.format, len(data), c.expected)
+		}
+	}
+}
+
+func Test_addCustomColumn_AlignLeft(t *testing.T) {
+	results := []*stats.BenchmarkResult{{Name: "a"}}
+	data := make([][]string, 0)
+
+	addCustomColumn(&data, results, 1, CustomColumn{
+		Title:  "LABEL",
+		Value:  func(result *stats.BenchmarkResult) any { return result.Name },
+		Format: options.ColumnFormat{Alignment: options.AlignLeft},
+	})
+
+	expected := []string{"LABEL", "-----", "a    "}
+	if !reflect.DeepEqual(expected, data[0]) {
+		t.Errorf("expected: %q, actual: %q", expected, data[0])
+	}
+}
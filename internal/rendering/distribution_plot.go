@@ -0,0 +1,212 @@
+package rendering
+
+import (
+	"math"
+	"strings"
+
+	"github.com/smarty/benchy/options"
+	"github.com/smarty/benchy/stats"
+)
+
+// DefaultWidth is the width that plots are fitted to when the width of the
+// terminal is unknown.
+const DefaultWidth = 100
+
+// minimumPlotWidth is the smallest number of columns used for the plot itself,
+// even when the names leave no room in the width.
+const minimumPlotWidth = 20
+
+// densityBlocks are the block characters for increasing densities, starting
+// with no samples.
+var densityBlocks = []rune(" ▁▂▃▄▅▆▇█")
+
+// DistributionPlot renders the samples of all `results` on a shared time
+// axis, in the `style` of a box plot or a violin plot. The lines are fitted to
+// `width` columns.
+//
+// Ansi codes from the `theme` are used to color the text.
+func DistributionPlot(results []*stats.BenchmarkResult, style options.PlotStyle, width int, theme options.Theme) []string {
+	if len(results) == 0 {
+		return nil
+	}
+
+	nameLength := 0
+	for _, result := range results {
+		nameLength = max(nameLength, stringLength(result.Name))
+	}
+
+	// leave room for the name, a space and the two borders of the plot
+	plotWidth := max(minimumPlotWidth, width-nameLength-3)
+	axis := newPlotAxis(results, plotWidth)
+
+	title := "box plot:"
+	if style == options.ViolinPlot {
+		title = "violin plot:"
+	}
+
+	// add a line for the title, one per result and two for the axis
+	lines := make([]string, 0, len(results)+3)
+	lines = append(lines, paint(theme.Text, title))
+	for _, result := range results {
+		plot := ""
+		if style == options.ViolinPlot {
+			plot = renderViolin(result, axis)
+		} else {
+			plot = renderBox(result, axis, theme)
+		}
+
+		lines = append(lines, paint(theme.Text, padRight(result.Name, nameLength, ' ')+" │"+plot+"│"))
+	}
+
+	lines = append(lines, paint(theme.Text, padRight("", nameLength, ' ')+" └"+padRight("", plotWidth, '─')+"┘"))
+	lines = append(lines, paint(theme.Text, padRight("", nameLength, ' ')+"  "+axis.renderLabels()))
+	return lines
+}
+
+// plotAxis maps durations to the columns of a plot.
+type plotAxis struct {
+	low   stats.Duration
+	high  stats.Duration
+	width int
+}
+
+func newPlotAxis(results []*stats.BenchmarkResult, width int) plotAxis {
+	axis := plotAxis{low: stats.Duration(math.Inf(1)), high: stats.Duration(math.Inf(-1)), width: width}
+	for _, result := range results {
+		for _, sample := range result.Samples {
+			axis.low = min(axis.low, sample)
+			axis.high = max(axis.high, sample)
+		}
+	}
+
+	if math.IsInf(float64(axis.low), 0) {
+		axis.low, axis.high = 0, 0
+	}
+
+	if axis.high <= axis.low {
+		axis.high = axis.low + 1
+	}
+
+	return axis
+}
+
+// column finds the column that `value` is drawn in.
+func (this plotAxis) column(value stats.Duration) int {
+	position := float64((value - this.low) / (this.high - this.low))
+	return min(this.width-1, max(0, int(math.Round(position*float64(this.width-1)))))
+}
+
+// value finds the duration that `column` is drawn for.
+func (this plotAxis) value(column int) stats.Duration {
+	return this.low + (this.high-this.low)*stats.Duration(column)/stats.Duration(max(1, this.width-1))
+}
+
+func (this plotAxis) renderLabels() string {
+	low := strings.TrimSpace(this.low.Render())
+	middleValue := this.value(this.width / 2)
+	middle := strings.TrimSpace(middleValue.Render())
+	high := strings.TrimSpace(this.high.Render())
+
+	labels := []rune(padRight(low, this.width, ' '))
+	start := this.width/2 - stringLength(middle)/2
+	if start > stringLength(low) && start+stringLength(middle) < this.width-stringLength(high) {
+		copy(labels[start:], []rune(middle))
+	}
+
+	copy(labels[this.width-stringLength(high):], []rune(high))
+	return string(labels)
+}
+
+func renderBox(result *stats.BenchmarkResult, axis plotAxis, theme options.Theme) string {
+	row := []rune(padRight("", axis.width, ' '))
+	outliers := make([]bool, axis.width)
+	if len(result.Samples) == 0 {
+		return string(row)
+	}
+
+	whiskerLow, whiskerHigh := coreRange(result)
+	for column := axis.column(whiskerLow); column <= axis.column(whiskerHigh); column++ {
+		row[column] = '─'
+	}
+
+	for column := axis.column(result.Quartile1); column <= axis.column(result.Quartile3); column++ {
+		row[column] = '▒'
+	}
+
+	row[axis.column(whiskerLow)] = '├'
+	row[axis.column(whiskerHigh)] = '┤'
+	row[axis.column(result.Median)] = '┃'
+	for _, outlier := range result.Outliers {
+		row[axis.column(outlier)] = '•'
+		outliers[axis.column(outlier)] = true
+	}
+
+	sb := strings.Builder{}
+	for column, character := range row {
+		if outliers[column] {
+			sb.WriteString(highlight(theme.Outlier, string(character), theme.Text))
+			continue
+		}
+
+		sb.WriteRune(character)
+	}
+
+	return sb.String()
+}
+
+// renderViolin estimates the density of the samples in every column with a
+// gaussian kernel, and draws it with blocks that are scaled to the densest
+// column.
+func renderViolin(result *stats.BenchmarkResult, axis plotAxis) string {
+	densities := make([]float64, axis.width)
+	if len(result.Samples) == 0 {
+		return padRight("", axis.width, ' ')
+	}
+
+	// Silverman's rule of thumb, but at least a column wide
+	step := float64(axis.value(1) - axis.value(0))
+	bandwidth := max(step, 1.06*float64(result.StandardDeviation)*math.Pow(float64(len(result.Samples)), -0.2))
+	highest := 0.0
+	for column := range densities {
+		x := float64(axis.value(column))
+		for _, sample := range result.Samples {
+			distance := (x - float64(sample)) / bandwidth
+			densities[column] += math.Exp(-0.5 * distance * distance)
+		}
+
+		highest = max(highest, densities[column])
+	}
+
+	sb := strings.Builder{}
+	for _, density := range densities {
+		level := int(math.Round(density / highest * float64(len(densityBlocks)-1)))
+		sb.WriteRune(densityBlocks[level])
+	}
+
+	return sb.String()
+}
+
+// coreRange finds the shortest and longest sample, excluding outliers.
+func coreRange(result *stats.BenchmarkResult) (low stats.Duration, high stats.Duration) {
+	outliers := make(map[stats.Duration]int)
+	for _, outlier := range result.Outliers {
+		outliers[outlier]++
+	}
+
+	low, high = stats.Duration(math.Inf(1)), stats.Duration(math.Inf(-1))
+	for _, sample := range result.Samples {
+		if outliers[sample] > 0 {
+			outliers[sample]--
+			continue
+		}
+
+		low = min(low, sample)
+		high = max(high, sample)
+	}
+
+	if math.IsInf(float64(low), 0) {
+		return result.Median, result.Median
+	}
+
+	return low, high
+}
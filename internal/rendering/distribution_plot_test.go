@@ -0,0 +1,53 @@
+package rendering
+
+import (
+	"testing"
+
+	"github.com/smarty/benchy/options"
+	"github.com/smarty/benchy/stats"
+)
+
+func Test_DistributionPlot_FitsWidth(t *testing.T) {
+	results := []*stats.BenchmarkResult{
+		{Name: "first", Samples: []stats.Duration{1, 2, 3, 4, 20}, Outliers: []stats.Duration{20}, Quartile1: 2, Median: 3, Quartile3: 4},
+		{Name: "second", Samples: []stats.Duration{5, 6, 7}, Quartile1: 5, Median: 6, Quartile3: 7},
+	}
+
+	for _, style := range []options.PlotStyle{options.BoxPlot, options.ViolinPlot} {
+		lines := DistributionPlot(results, style, 60, options.NoColorTheme())
+
+		for _, line := range lines[1:] {
+			if stringLength(line) > 60 {
+				t.Errorf("line %q is %d columns wide, want at most 60", line, stringLength(line))
+			}
+		}
+	}
+}
+
+func Test_renderBox(t *testing.T) {
+	result := &stats.BenchmarkResult{
+		Samples:   []stats.Duration{0, 2, 4, 5, 6, 10},
+		Outliers:  []stats.Duration{10},
+		Quartile1: 2,
+		Median:    4,
+		Quartile3: 5,
+	}
+	axis := plotAxis{low: 0, high: 10, width: 11}
+
+	actual := renderBox(result, axis, options.NoColorTheme())
+
+	expected := "├─▒▒┃▒┤   •"
+	if actual != expected {
+		t.Errorf("renderBox() is %q, want %q", actual, expected)
+	}
+}
+
+func Test_coreRange_ExcludesOutliers(t *testing.T) {
+	result := &stats.BenchmarkResult{Samples: []stats.Duration{1, 5, 5, 9}, Outliers: []stats.Duration{1, 9}}
+
+	low, high := coreRange(result)
+
+	if low != 5 || high != 5 {
+		t.Errorf("coreRange() is (%v, %v), want (5, 5)", low, high)
+	}
+}
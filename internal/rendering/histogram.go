@@ -2,39 +2,73 @@ package rendering
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
+	"github.com/smarty/benchy/options"
 	. "github.com/smarty/benchy/stats"
 )
 
+// verticalHistogramHeight is the number of lines used for the bars of a
+// vertical histogram.
+const verticalHistogramHeight = 10
+
+// histogramBucket is a range of durations and the samples that fall in it.
+type histogramBucket struct {
+	low      Duration
+	high     Duration
+	count    int
+	outliers int
+}
+
 // Histogram renders the histogram as a series of lines which can be written out.
+// The bars are scaled down when they would not fit in `width` columns.
 //
-// Ansi codes are used to color the text.
-func Histogram(result BenchmarkResult) []string {
+// Ansi codes from the `theme` are used to color the text.
+func Histogram(result BenchmarkResult, style options.HistogramStyle, width int, theme options.Theme) []string {
+	buckets := linearBuckets(result)
+	if style.LogScale && result.Min > 0 {
+		buckets = logBuckets(result)
+	}
+
 	// add a line for the header and for the footer
-	lines := make([]string, 0, len(result.Histogram)+2)
-	lines = append(lines, fmt.Sprintf(
-		"%s%s: %s%s",
-		ansi_cyan,
-		result.Name,
-		ansi_reset,
-		generateModalityString(result)))
+	lines := make([]string, 0, len(buckets)+2)
+	lines = append(lines, paint(theme.Text, result.Name+": ")+generateModalityString(result, theme))
+	if len(buckets) == 0 {
+		return lines
+	}
 
-	start, step := calculateStepping(result.Histogram, result.Min, result.Max)
-	low := start
-	high := start
+	if style.Vertical {
+		lines = append(lines, renderVerticalBars(buckets, width, theme)...)
+	} else {
+		lines = append(lines, renderHorizontalBars(buckets, width, theme)...)
+	}
+
+	if len(result.Outliers) > 0 {
+		lines = append(lines, listOutliers(result, theme))
+	}
+
+	return lines
+}
+
+func renderHorizontalBars(buckets []histogramBucket, width int, theme options.Theme) []string {
+	labels := make([]string, len(buckets))
+	labelLength := 0
+	highest := 0
+	for iBucket, bucket := range buckets {
+		labels[iBucket] = fmt.Sprintf("%s - %s", bucket.low.Render(), bucket.high.Render())
+		labelLength = max(labelLength, stringLength(labels[iBucket]))
+		highest = max(highest, bucket.count)
+	}
+
+	// leave room for the label, the separator and the count
+	barLength := min(highest, max(1, width-labelLength-3-len(fmt.Sprint(highest))))
+	lines := make([]string, 0, len(buckets))
 	truncatedPrevious := false
-	for bucketNumber, numberInBucket := range result.Histogram {
-		low = high
-		high = low + step
-		if shouldSkipBucket(result, numberInBucket, bucketNumber) {
+	for iBucket, bucket := range buckets {
+		if shouldSkipBucket(buckets, iBucket) {
 			if !truncatedPrevious {
-				lines = append(lines, fmt.Sprintf(
-					"%s%s...%s",
-					ansi_cyan,
-					padRight("", 25, ' '),
-					ansi_reset))
-
+				lines = append(lines, paint(theme.Text, padLeft("...", labelLength, ' ')))
 				truncatedPrevious = true
 			}
 
@@ -43,31 +77,157 @@ func Histogram(result BenchmarkResult) []string {
 
 		truncatedPrevious = false
 		number := ""
-		if numberInBucket != 0 {
-			number = fmt.Sprintf("%d", numberInBucket)
+		if bucket.count != 0 {
+			number = fmt.Sprintf("%d", bucket.count)
 		}
 
 		lines = append(lines, fmt.Sprintf(
-			"%s%s |%s %s%s",
-			ansi_cyan,
-			padLeft(fmt.Sprintf("%s - %s", low.Render(), high.Render()), 25, ' '),
-			renderBucketSamples(result, bucketNumber, low, high),
-			number,
-			ansi_reset))
+			"%s%s%s",
+			paint(theme.Text, padLeft(labels[iBucket], labelLength, ' ')+" |"),
+			renderBucketSamples(bucket, iBucket < len(buckets)/2, highest, barLength, theme),
+			paint(theme.Text, " "+number)))
 	}
 
-	if len(result.Outliers) > 0 {
-		lines = append(lines, listOutliers(result))
+	return lines
+}
+
+// shouldSkipBucket determines if the bucket is empty and between empty buckets,
+// so that long gaps can be shortened.
+func shouldSkipBucket(buckets []histogramBucket, bucketNumber int) bool {
+	isEmpty := func(number int) bool {
+		return number < 0 || number >= len(buckets) || buckets[number].count == 0
+	}
+
+	return isEmpty(bucketNumber-1) && isEmpty(bucketNumber) && isEmpty(bucketNumber+1)
+}
+
+// renderBucketSamples draws the bar of a bucket, scaled so that the bucket with
+// the `highest` count fills `barLength` columns. Outliers are drawn on the
+// outer side of the bar.
+func renderBucketSamples(bucket histogramBucket, lowerHalf bool, highest int, barLength int, theme options.Theme) string {
+	length := scaleCount(bucket.count, highest, barLength)
+	outlierLength := min(length, scaleCount(bucket.outliers, highest, barLength))
+	outliers := padRight("", outlierLength, 'X')
+	regular := padRight("", length-outlierLength, 'X')
+	blanks := padRight("", barLength-length, ' ')
+	if lowerHalf {
+		return paint(theme.Outlier, outliers) + paint(theme.Text, regular+blanks)
+	}
+
+	return paint(theme.Text, regular) + paint(theme.Outlier, outliers) + paint(theme.Text, blanks)
+}
+
+func renderVerticalBars(buckets []histogramBucket, width int, theme options.Theme) []string {
+	highest := 0
+	for _, bucket := range buckets {
+		highest = max(highest, bucket.count)
+	}
+
+	countLength := len(fmt.Sprint(highest))
+	// leave room for the count and the axis on the left
+	barWidth := min(3, max(1, (width-countLength-2)/max(1, len(buckets))))
+	height := min(highest, verticalHistogramHeight)
+	plotWidth := barWidth * len(buckets)
+
+	// add two lines for the axis and its labels
+	lines := make([]string, 0, height+2)
+	for level := height; level >= 1; level-- {
+		label := ""
+		if level == height {
+			label = fmt.Sprint(highest)
+		}
+
+		sb := strings.Builder{}
+		for _, bucket := range buckets {
+			length := scaleCount(bucket.count, highest, height)
+			outlierLength := min(length, scaleCount(bucket.outliers, highest, height))
+			block := padRight("", barWidth, ' ')
+			if level <= length {
+				block = padRight("", barWidth, '█')
+			}
+
+			// outliers are drawn on top of the bar
+			if level <= length && level > length-outlierLength {
+				sb.WriteString(highlight(theme.Outlier, block, theme.Text))
+				continue
+			}
+
+			sb.WriteString(block)
+		}
+
+		lines = append(lines, paint(theme.Text, padLeft(label, countLength, ' ')+" │"+sb.String()))
+	}
+
+	axis := plotAxis{low: buckets[0].low, high: buckets[len(buckets)-1].high, width: plotWidth}
+	lines = append(lines, paint(theme.Text, padLeft("", countLength, ' ')+" └"+padRight("", plotWidth, '─')))
+	if plotWidth >= minimumPlotWidth {
+		lines = append(lines, paint(theme.Text, padLeft("", countLength, ' ')+"  "+axis.renderLabels()))
+	} else {
+		lines = append(lines, paint(theme.Text, fmt.Sprintf(
+			"%s  %s - %s",
+			padLeft("", countLength, ' '),
+			strings.TrimSpace(axis.low.Render()),
+			strings.TrimSpace(axis.high.Render()))))
 	}
 
 	return lines
 }
 
-func shouldSkipBucket(result BenchmarkResult, numberInBucket int, bucketNumber int) bool {
-	return numberInBucket == 0 && result.Histogram[bucketNumber-1] == 0 && result.Histogram[bucketNumber+1] == 0
+// scaleCount scales `count` so that `highest` is `length` long. A non-zero
+// count is at least 1 long.
+func scaleCount(count int, highest int, length int) int {
+	if count == 0 || highest <= length {
+		return count
+	}
+
+	return max(1, int(math.Round(float64(count)*float64(length)/float64(highest))))
 }
 
-func renderBucketSamples(result BenchmarkResult, bucketNumber int, low Duration, high Duration) string {
+// linearBuckets divides the range of the samples into the buckets of equal
+// width that were used to calculate the modality.
+func linearBuckets(result BenchmarkResult) []histogramBucket {
+	start, step := calculateStepping(result.Histogram, result.Min, result.Max)
+	buckets := make([]histogramBucket, len(result.Histogram))
+	for bucketNumber, numberInBucket := range result.Histogram {
+		low := start + Duration(bucketNumber)*step
+		buckets[bucketNumber] = histogramBucket{
+			low:      low,
+			high:     low + step,
+			count:    numberInBucket,
+			outliers: countOutliers(result, low, low+step),
+		}
+	}
+
+	return buckets
+}
+
+// logBuckets divides the range of the samples into as many buckets as the
+// linear histogram, where every bucket is a fixed factor wider than the
+// previous one. The range must be above zero.
+func logBuckets(result BenchmarkResult) []histogramBucket {
+	bucketCount := max(1, len(result.Histogram))
+	factor := math.Pow(float64(result.Max/result.Min), 1/float64(bucketCount))
+	buckets := make([]histogramBucket, bucketCount)
+	low := result.Min
+	for bucketNumber := range buckets {
+		high := Duration(float64(result.Min) * math.Pow(factor, float64(bucketNumber+1)))
+		buckets[bucketNumber] = histogramBucket{low: low, high: high, outliers: countOutliers(result, low, high)}
+		low = high
+	}
+
+	for _, sample := range result.Samples {
+		bucketNumber := int(math.Log(float64(sample/result.Min)) / math.Log(factor))
+		if factor <= 1 || bucketNumber < 0 {
+			bucketNumber = 0
+		}
+
+		buckets[min(bucketNumber, bucketCount-1)].count++
+	}
+
+	return buckets
+}
+
+func countOutliers(result BenchmarkResult, low Duration, high Duration) int {
 	outliersInBucket := 0
 	for _, outlier := range result.Outliers {
 		if outlier >= low-0.0001 && outlier <= high+0.0001 {
@@ -75,15 +235,7 @@ func renderBucketSamples(result BenchmarkResult, bucketNumber int, low Duration,
 		}
 	}
 
-	numberInBucket := result.Histogram[bucketNumber]
-	outliers := padRight("", outliersInBucket, 'X')
-	regular := padRight("", numberInBucket-outliersInBucket, 'X')
-	blanks := padRight("", len(result.Samples)-numberInBucket, ' ')
-	if bucketNumber < len(result.Histogram)/2 {
-		return fmt.Sprintf("%s%s%s%s%s", ansi_yellow, outliers, ansi_cyan, regular, blanks)
-	}
-
-	return fmt.Sprintf("%s%s%s%s%s", regular, ansi_yellow, outliers, ansi_cyan, blanks)
+	return outliersInBucket
 }
 
 func calculateStepping(histogram []int, minimum Duration, maximum Duration) (start Duration, step Duration) {
@@ -92,25 +244,16 @@ func calculateStepping(histogram []int, minimum Duration, maximum Duration) (sta
 	return start, step
 }
 
-func generateModalityString(result BenchmarkResult) string {
+func generateModalityString(result BenchmarkResult, theme options.Theme) string {
 	if result.Modality == 1 {
-		return fmt.Sprintf("%suni-modal%s", ansi_cyan, ansi_reset)
-	}
-
-	category := "multi"
-	switch result.Modality {
-	case 0:
-		category = "non"
-	case 2:
-		category = "bi"
+		return paint(theme.Text, modalityName(result.Modality))
 	}
 
-	return fmt.Sprintf("%s%s-modal%s", ansi_yellow, category, ansi_reset)
+	return paint(theme.Warning, modalityName(result.Modality))
 }
 
-func listOutliers(result BenchmarkResult) string {
+func listOutliers(result BenchmarkResult, theme options.Theme) string {
 	sb := strings.Builder{}
-	sb.WriteString(ansi_yellow)
 
 	preamble := "outliers were"
 	if len(result.Outliers) == 1 {
@@ -127,6 +270,5 @@ func listOutliers(result BenchmarkResult) string {
 	}
 
 	sb.WriteString(" ]")
-	sb.WriteString(ansi_reset)
-	return sb.String()
+	return paint(theme.Outlier, sb.String())
 }
@@ -0,0 +1,71 @@
+package rendering
+
+import (
+	"testing"
+
+	"github.com/smarty/benchy/options"
+	"github.com/smarty/benchy/stats"
+)
+
+func Test_shouldSkipBucket_Bounds(t *testing.T) {
+	buckets := []histogramBucket{{count: 0}, {count: 0}, {count: 3}, {count: 0}}
+
+	if !shouldSkipBucket(buckets, 0) {
+		t.Error("the first bucket is empty and before an empty bucket, it should be skipped")
+	}
+
+	if shouldSkipBucket(buckets, 1) {
+		t.Error("the second bucket is next to a full bucket, it should not be skipped")
+	}
+
+	if shouldSkipBucket(buckets, 3) {
+		t.Error("the last bucket is next to a full bucket, it should not be skipped")
+	}
+}
+
+func Test_scaleCount(t *testing.T) {
+	cases := []struct{ count, highest, length, expected int }{
+		{count: 5, highest: 10, length: 20, expected: 5},
+		{count: 500, highest: 500, length: 50, expected: 50},
+		{count: 1, highest: 500, length: 50, expected: 1},
+		{count: 0, highest: 500, length: 50, expected: 0},
+	}
+
+	for _, c := range cases {
+		actual := scaleCount(c.count, c.highest, c.length)
+
+		if actual != c.expected {
+			t.Errorf("scaleCount(%d, %d, %d) is %d, want %d", c.count, c.highest, c.length, actual, c.expected)
+		}
+	}
+}
+
+func Test_logBuckets(t *testing.T) {
+	result := stats.BenchmarkResult{
+		Samples:   []stats.Duration{1, 5, 10, 100, 1000},
+		Histogram: make([]int, 3),
+		Min:       1,
+		Max:       1000,
+	}
+
+	buckets := logBuckets(result)
+
+	expected := []int{2, 1, 2}
+	for iBucket, bucket := range buckets {
+		if bucket.count != expected[iBucket] {
+			t.Errorf("bucket %d has %d samples, want %d", iBucket, bucket.count, expected[iBucket])
+		}
+	}
+}
+
+func Test_Histogram_FitsWidth(t *testing.T) {
+	result := stats.BenchmarkResult{Name: "wide", Histogram: []int{400, 0, 0, 0, 100}, Min: 1_000, Max: 2_000}
+
+	lines := Histogram(result, options.HistogramStyle{}, 60, options.NoColorTheme())
+
+	for _, line := range lines {
+		if stringLength(line) > 60 {
+			t.Errorf("line %q is %d columns wide, want at most 60", line, stringLength(line))
+		}
+	}
+}
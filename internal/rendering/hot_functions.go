@@ -0,0 +1,63 @@
+package rendering
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smarty/benchy/options"
+	"github.com/smarty/benchy/stats"
+)
+
+// HotFunctionCount is the number of functions shown by HotFunctions.
+const HotFunctionCount = 10
+
+// HotFunctions renders the functions which used the most CPU time as a table,
+// in the style of `go tool pprof -top`.
+//
+// Ansi codes from the `theme` are used to color the text.
+func HotFunctions(result stats.BenchmarkResult, theme options.Theme) []string {
+	if len(result.HotFunctions) == 0 {
+		return nil
+	}
+
+	functions := result.HotFunctions[:min(HotFunctionCount, len(result.HotFunctions))]
+
+	// add a line for the title and two lines for the table header
+	lines := make([]string, 0, len(functions)+3)
+	lines = append(lines, paint(theme.Text, fmt.Sprintf("%s hot functions:", result.Name)))
+
+	durationLength := stringLength("CUM")
+	for _, function := range functions {
+		durationLength = max(durationLength, function.Flat.RenderLength(), function.Cumulative.RenderLength())
+	}
+
+	percentLength := stringLength("100.00%")
+	header := []string{
+		padLeft("FLAT", durationLength, ' '),
+		padLeft("FLAT%", percentLength, ' '),
+		padLeft("CUM", durationLength, ' '),
+		padLeft("CUM%", percentLength, ' '),
+		"FUNCTION",
+	}
+
+	lines = append(lines, paint(theme.Text, strings.Join(header, " | ")))
+	lines = append(lines, paint(theme.Text, fmt.Sprintf(
+		"%s-+-%s-+-%s-+-%s-+-%s",
+		padLeft("", durationLength, '-'),
+		padLeft("", percentLength, '-'),
+		padLeft("", durationLength, '-'),
+		padLeft("", percentLength, '-'),
+		padLeft("", stringLength("FUNCTION"), '-'))))
+
+	for _, function := range functions {
+		lines = append(lines, paint(theme.Text, fmt.Sprintf(
+			"%s | %s | %s | %s | %s",
+			padLeft(function.Flat.Render(), durationLength, ' '),
+			padLeft(fmt.Sprintf("%0.2f%%", function.FlatPercent), percentLength, ' '),
+			padLeft(function.Cumulative.Render(), durationLength, ' '),
+			padLeft(fmt.Sprintf("%0.2f%%", function.CumulativePercent), percentLength, ' '),
+			function.Name)))
+	}
+
+	return lines
+}
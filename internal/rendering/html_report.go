@@ -0,0 +1,153 @@
+package rendering
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/smarty/benchy/stats"
+)
+
+//go:embed html_report.tmpl
+var htmlReportTemplate string
+
+var htmlReport = template.Must(template.New("report").Parse(htmlReportTemplate))
+
+// ReportMetadata describes the environment that the benchmarks were run in.
+type ReportMetadata struct {
+	Generated    time.Time
+	GoVersion    string
+	OS           string
+	Architecture string
+	CPUs         int
+}
+
+// CurrentMetadata describes the environment of the running process.
+func CurrentMetadata() ReportMetadata {
+	return ReportMetadata{
+		Generated:    time.Now(),
+		GoVersion:    runtime.Version(),
+		OS:           runtime.GOOS,
+		Architecture: runtime.GOARCH,
+		CPUs:         runtime.NumCPU(),
+	}
+}
+
+type htmlReportData struct {
+	Metadata ReportMetadata
+	Runs     []htmlRun
+}
+
+type htmlRun struct {
+	Title      string
+	Table      htmlTable
+	BoxPlot    template.HTML
+	Benchmarks []htmlBenchmark
+}
+
+type htmlTable struct {
+	Headers []string
+	Rows    [][]string
+}
+
+type htmlBenchmark struct {
+	Name      string
+	Modality  string
+	Warning   bool
+	Histogram template.HTML
+	Scatter   template.HTML
+}
+
+// HTMLReport writes a self-contained html page for one or more `runs`, with
+// the report card of every run and svg charts of every benchmark. The page
+// does not load anything else.
+func HTMLReport(writer io.Writer, metadata ReportMetadata, runs ...[]*stats.BenchmarkResult) error {
+	data := htmlReportData{Metadata: metadata, Runs: make([]htmlRun, 0, len(runs))}
+	for iRun, results := range runs {
+		run := htmlRun{
+			Title:      fmt.Sprintf("Run %d", iRun+1),
+			Table:      htmlReportCard(results),
+			BoxPlot:    svgBoxPlot(results),
+			Benchmarks: make([]htmlBenchmark, 0, len(results)),
+		}
+
+		for _, result := range results {
+			run.Benchmarks = append(run.Benchmarks, htmlBenchmark{
+				Name:      result.Name,
+				Modality:  modalityName(result.Modality),
+				Warning:   result.Modality != 1,
+				Histogram: svgHistogram(result),
+				Scatter:   svgScatter(result),
+			})
+		}
+
+		data.Runs = append(data.Runs, run)
+	}
+
+	return htmlReport.Execute(writer, data)
+}
+
+func htmlReportCard(results []*stats.BenchmarkResult) htmlTable {
+	hasBytes := false
+	hasItems := false
+	for _, result := range results {
+		hasBytes = hasBytes || result.BytesPerOp > 0
+		hasItems = hasItems || result.ItemsPerOp > 0
+	}
+
+	table := htmlTable{Headers: []string{
+		"Benchmark", "Samples", "Average", "Median", "Min", "Max", "Std Dev", "Std Err", "4σ", "Allocations", "Memory Growth",
+	}}
+	if hasBytes {
+		table.Headers = append(table.Headers, "Bytes/s")
+	}
+
+	if hasItems {
+		table.Headers = append(table.Headers, "Items/s")
+	}
+
+	for _, result := range results {
+		row := []string{
+			result.Name,
+			fmt.Sprint(len(result.Samples)),
+			renderTrimmed(result.Average),
+			renderTrimmed(result.Median),
+			renderTrimmed(result.Min),
+			renderTrimmed(result.Max),
+			renderTrimmed(result.StandardDeviation),
+			renderTrimmed(result.StandardError),
+			renderTrimmed(result.FourSigma),
+			fmt.Sprintf("%0.3f", result.Allocations),
+			fmt.Sprintf("%0.3f", result.MemoryGrowth),
+		}
+
+		if hasBytes {
+			row = append(row, strings.TrimSpace(result.ByteThroughput.Average.Render(stats.BytesSuffix)))
+		}
+
+		if hasItems {
+			row = append(row, strings.TrimSpace(result.ItemThroughput.Average.Render(stats.ItemsSuffix)))
+		}
+
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table
+}
+
+func modalityName(modality int) string {
+	switch modality {
+	case 0:
+		return "non-modal"
+	case 1:
+		return "uni-modal"
+	case 2:
+		return "bi-modal"
+	default:
+		return "multi-modal"
+	}
+}
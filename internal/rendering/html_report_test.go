@@ -0,0 +1,39 @@
+package rendering
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/smarty/benchy/stats"
+)
+
+func TestHTMLReport_IsSelfContained(t *testing.T) {
+	result := &stats.BenchmarkResult{
+		Name:    "<script>",
+		Samples: []stats.Duration{5, 4, 3, 2, 1, 2, 3, 4, 5, 40},
+	}
+	stats.CalculateFullResultStatistics(result)
+	buffer := &bytes.Buffer{}
+
+	err := HTMLReport(buffer, CurrentMetadata(), []*stats.BenchmarkResult{result}, []*stats.BenchmarkResult{result})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report := buffer.String()
+	if strings.Contains(report, "<script>") {
+		t.Error("the benchmark name was not escaped")
+	}
+
+	// a box plot for every run, and a histogram and a scatter plot for every benchmark
+	if count := strings.Count(report, "<svg"); count != 6 {
+		t.Errorf("the report has %d svg images, want 6", count)
+	}
+
+	for _, external := range []string{"src=", "href=", "@import"} {
+		if strings.Contains(report, external) {
+			t.Errorf("the report refers to an external asset with %q", external)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package rendering
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/smarty/benchy/stats"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName  string          `xml:"classname,attr"`
+	Name       string          `xml:"name,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	Failure    *junitFailure   `xml:"failure,omitempty"`
+	SystemOut  string          `xml:"system-out,omitempty"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// JUnitReport writes the `assertions` as JUnit XML, with a test suite for every
+// test that made assertions and a test case for every assertion. The measured
+// values are written as properties of the test case and as its output.
+func JUnitReport(writer io.Writer, assertions []stats.Assertion) (err error) {
+	report := junitTestSuites{}
+	suites := make(map[string]int)
+	for _, assertion := range assertions {
+		iSuite, found := suites[assertion.Test]
+		if !found {
+			iSuite = len(report.Suites)
+			suites[assertion.Test] = iSuite
+			report.Suites = append(report.Suites, junitTestSuite{Name: assertion.Test})
+		}
+
+		testCase := junitTestCase{
+			ClassName:  assertion.Test,
+			Name:       assertionTitle(assertion),
+			Properties: assertionProperties(assertion),
+			SystemOut:  strings.Join(assertionValues(assertion), "\n"),
+		}
+
+		report.Tests++
+		report.Suites[iSuite].Tests++
+		if !assertion.Passed {
+			report.Failures++
+			report.Suites[iSuite].Failures++
+			testCase.Failure = &junitFailure{
+				Message: firstLine(assertion.Message),
+				Type:    assertion.Name,
+				Text:    assertion.Message,
+			}
+		}
+
+		report.Suites[iSuite].Cases = append(report.Suites[iSuite].Cases, testCase)
+	}
+
+	if _, err = io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(writer, "\n")
+	return err
+}
+
+// assertionTitle names the test case after the operator and the benchmarks,
+// such as "fibWithCache FasterThan fib".
+func assertionTitle(assertion stats.Assertion) string {
+	parts := append([]string{assertion.Left, assertion.Name}, assertion.Right...)
+	return strings.Join(parts, " ")
+}
+
+func assertionProperties(assertion stats.Assertion) []junitProperty {
+	properties := []junitProperty{{Name: "assertion", Value: assertion.Name}}
+	for _, value := range assertion.Values {
+		properties = append(properties,
+			junitProperty{Name: value.Benchmark + ".average", Value: renderTrimmed(value.Average)},
+			junitProperty{Name: value.Benchmark + ".median", Value: renderTrimmed(value.Median)},
+			junitProperty{Name: value.Benchmark + ".allocations", Value: fmt.Sprintf("%0.2f", value.Allocations)})
+		for _, metric := range value.Metrics {
+			properties = append(properties, junitProperty{
+				Name:  value.Benchmark + "." + metric.Name,
+				Value: fmt.Sprintf("%g %s", metric.Average, metric.Unit),
+			})
+		}
+	}
+
+	return properties
+}
+
+// assertionValues lists the measured values of every benchmark in the
+// assertion, one benchmark per line.
+func assertionValues(assertion stats.Assertion) []string {
+	lines := make([]string, 0, len(assertion.Values))
+	for _, value := range assertion.Values {
+		lines = append(lines, fmt.Sprintf(
+			"%s: average %s, median %s, %0.2f allocations/op",
+			value.Benchmark,
+			renderTrimmed(value.Average),
+			renderTrimmed(value.Median),
+			value.Allocations))
+	}
+
+	return lines
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	return line
+}
@@ -0,0 +1,62 @@
+package rendering
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/smarty/benchy/stats"
+)
+
+func TestJUnitReport(t *testing.T) {
+	assertions := []stats.Assertion{
+		{
+			Test:   "BenchmarkFib",
+			Name:   "FasterThan",
+			Left:   "cached",
+			Right:  []string{"plain"},
+			Passed: true,
+			Values: []stats.AssertedValue{
+				{Benchmark: "cached", Average: 1_000, Median: 1_000},
+				{Benchmark: "plain", Average: 2_000, Median: 2_000},
+			},
+		},
+		{
+			Test:    "BenchmarkFib",
+			Name:    "NonAllocating",
+			Left:    "plain",
+			Message: "expected \"plain\" to not allocate",
+			Values:  []stats.AssertedValue{{Benchmark: "plain", Average: 2_000, Allocations: 3}},
+		},
+	}
+	buffer := &bytes.Buffer{}
+
+	err := JUnitReport(buffer, assertions)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	report := junitTestSuites{}
+	if err = xml.Unmarshal(buffer.Bytes(), &report); err != nil {
+		t.Fatalf("expected valid XML, got %v", err)
+	}
+
+	if report.Tests != 2 || report.Failures != 1 || len(report.Suites) != 1 {
+		t.Fatalf("expected 2 tests and 1 failure in 1 suite, got %+v", report)
+	}
+
+	passed, failed := report.Suites[0].Cases[0], report.Suites[0].Cases[1]
+	if passed.Name != "cached FasterThan plain" || passed.Failure != nil {
+		t.Errorf("expected a passing \"cached FasterThan plain\", got %+v", passed)
+	}
+
+	if failed.Failure == nil || failed.Failure.Type != "NonAllocating" {
+		t.Errorf("expected a NonAllocating failure, got %+v", failed)
+	}
+
+	if !strings.Contains(failed.SystemOut, "plain: average 2.000 µs") {
+		t.Errorf("expected the measured values in the output, got %q", failed.SystemOut)
+	}
+}
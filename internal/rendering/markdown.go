@@ -0,0 +1,157 @@
+package rendering
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strings"
+
+	"github.com/smarty/benchy/options"
+	"github.com/smarty/benchy/stats"
+)
+
+// markdownWidth is the width that histograms in Markdown are fitted to, which
+// fits a pull request comment without scrolling.
+const markdownWidth = 80
+
+// MarkdownReport renders a Markdown document with the environment that the
+// benchmarks were run in, the report card and the details of every result.
+func MarkdownReport(results []*stats.BenchmarkResult, sampleCount int, metadata ReportMetadata, settings ReportCardSettings, extraFuncs ...ExtraRenderingFunc) []string {
+	lines := []string{
+		"## Benchmark results",
+		"",
+		fmt.Sprintf(
+			"%s on %s/%s with %d CPUs, %d samples",
+			metadata.GoVersion,
+			metadata.OS,
+			metadata.Architecture,
+			metadata.CPUs,
+			sampleCount),
+		"",
+	}
+
+	lines = append(lines, MarkdownReportCard(results, sampleCount, settings, extraFuncs...)...)
+	lines = append(lines, "")
+	return append(lines, MarkdownDetails(results, sampleCount)...)
+}
+
+// MarkdownReportCard renders the report card as a Markdown table, from the same
+// columns as ReportCard. The theme of the `settings` is not used.
+func MarkdownReportCard(results []*stats.BenchmarkResult, sampleCount int, settings ReportCardSettings, extraFuncs ...ExtraRenderingFunc) []string {
+	settings.Theme = options.NoColorTheme()
+	data := reportCardData(results, sampleCount, settings, extraFuncs)
+
+	// the second line of every column is the header separator
+	lines := make([]string, len(results)+2)
+	for iLine := range lines {
+		cells := make([]string, len(data))
+		for iColumn, column := range data {
+			switch {
+			case iLine == 1 && iColumn == 0:
+				cells[iColumn] = ":---"
+			case iLine == 1:
+				cells[iColumn] = "---:"
+			default:
+				cells[iColumn] = markdownCell(column[iLine])
+			}
+		}
+
+		lines[iLine] = markdownRow(cells...)
+	}
+
+	return lines
+}
+
+// MarkdownDetails renders a collapsible section for every result, with its
+// histogram, outliers and hot functions.
+func MarkdownDetails(results []*stats.BenchmarkResult, sampleCount int) []string {
+	lines := make([]string, 0)
+	for _, result := range results {
+		lines = append(lines,
+			"<details>",
+			fmt.Sprintf(
+				"<summary><b>%s</b>: %s, %d samples</summary>",
+				html.EscapeString(result.Name),
+				modalityName(result.Modality),
+				len(result.Samples)),
+			"",
+			"```")
+
+		if sampleCount >= stats.MinFullCalculation {
+			// the name and modality are already in the summary
+			lines = append(lines, Histogram(*result, options.HistogramStyle{}, markdownWidth, options.NoColorTheme())[1:]...)
+		} else {
+			lines = append(lines, fmt.Sprintf("average: %s", renderTrimmed(result.Average)))
+		}
+
+		if len(result.HotFunctions) > 0 {
+			lines = append(lines, "")
+			lines = append(lines, HotFunctions(*result, options.NoColorTheme())...)
+		}
+
+		lines = append(lines, "```", "", "</details>")
+	}
+
+	return lines
+}
+
+// MarkdownComparison renders a Markdown table that compares the average of
+// every result in `target` to the result with the same name in `base`, for
+// example the results of a pull request to those of the main branch.
+//
+// A change is marked as faster or slower when it is larger than twice the
+// combined standard error of both averages, otherwise it is marked with "~".
+func MarkdownComparison(base []*stats.BenchmarkResult, target []*stats.BenchmarkResult) []string {
+	lines := make([]string, 0, len(target)+2)
+	lines = append(lines,
+		markdownRow("Benchmark", "Base", "Target", "Delta", ""),
+		markdownRow(":---", "---:", "---:", "---:", ":---:"))
+
+	for _, targetResult := range target {
+		baseResult := findBaseline(base, targetResult.Name, false)
+		if baseResult == nil {
+			lines = append(lines, markdownRow(
+				markdownCell(targetResult.Name), "-", renderTrimmed(targetResult.Average), "-", "new"))
+			continue
+		}
+
+		lines = append(lines, markdownRow(
+			markdownCell(targetResult.Name),
+			renderTrimmed(baseResult.Average),
+			renderTrimmed(targetResult.Average),
+			renderDelta(targetResult.Average, baseResult.Average),
+			significanceMarker(baseResult, targetResult)))
+	}
+
+	return lines
+}
+
+// significanceMarker marks the change from `base` to `target`.
+func significanceMarker(base *stats.BenchmarkResult, target *stats.BenchmarkResult) string {
+	if !isSignificant(base, target) {
+		return "~"
+	}
+
+	if target.Average < base.Average {
+		return "✅ faster"
+	}
+
+	return "❌ slower"
+}
+
+// isSignificant determines if the averages of two results differ by more than
+// twice their combined standard error, which is roughly a 95% confidence.
+func isSignificant(base *stats.BenchmarkResult, target *stats.BenchmarkResult) bool {
+	combinedError := math.Sqrt(float64(base.StandardError*base.StandardError + target.StandardError*target.StandardError))
+	return math.Abs(float64(target.Average-base.Average)) > 2*combinedError
+}
+
+func markdownRow(cells ...string) string {
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+// markdownCell trims the padding used for aligned text, and escapes the pipes
+// which would otherwise end the cell.
+func markdownCell(value string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(value), " "), "|", `\|`)
+}
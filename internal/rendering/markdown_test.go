@@ -0,0 +1,48 @@
+package rendering
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/smarty/benchy/stats"
+)
+
+func TestMarkdownComparison(t *testing.T) {
+	base := []*stats.BenchmarkResult{
+		{Name: "faster", Average: 2_000, StandardError: 10},
+		{Name: "noisy", Average: 1_000, StandardError: 100},
+	}
+	target := []*stats.BenchmarkResult{
+		{Name: "faster", Average: 1_000, StandardError: 10},
+		{Name: "noisy", Average: 1_100, StandardError: 100},
+		{Name: "a|b", Average: 1_000},
+	}
+
+	actual := MarkdownComparison(base, target)
+
+	expected := []string{
+		"| Benchmark | Base | Target | Delta |  |",
+		"| :--- | ---: | ---: | ---: | :---: |",
+		"| faster | 2.000 µs | 1.000 µs | -50.0% | ✅ faster |",
+		"| noisy | 1.000 µs | 1.100 µs | +10.0% | ~ |",
+		`| a\|b | - | 1.000 µs | - | new |`,
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected:\n%q\nactual:\n%q", expected, actual)
+	}
+}
+
+func TestMarkdownReportCard(t *testing.T) {
+	results := []*stats.BenchmarkResult{{Name: "first", Average: 1_000}}
+
+	actual := MarkdownReportCard(results, 1, ReportCardSettings{})
+
+	expected := []string{
+		"| BENCHMARK | AVERAGE |",
+		"| :--- | ---: |",
+		"| first | 1.000 µs |",
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected:\n%q\nactual:\n%q", expected, actual)
+	}
+}
@@ -0,0 +1,61 @@
+package rendering
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/smarty/benchy/internal/profiling"
+	"github.com/smarty/benchy/options"
+)
+
+// ProfileDiffCount is the number of functions shown by ProfileDiff.
+const ProfileDiffCount = 10
+
+// ProfileDiff renders the functions whose share of CPU time changed the most
+// between two benchmarks as a table.
+//
+// Ansi codes from the `theme` are used to color the text. Functions which
+// gained share are shown in the warning color.
+func ProfileDiff(baseName string, targetName string, deltas []profiling.FunctionDelta, theme options.Theme) []string {
+	if len(deltas) == 0 {
+		return nil
+	}
+
+	deltas = deltas[:min(ProfileDiffCount, len(deltas))]
+
+	// add a line for the title and two lines for the table header
+	lines := make([]string, 0, len(deltas)+3)
+	lines = append(lines, paint(theme.Text, fmt.Sprintf("profile of \"%s\" compared to \"%s\":", targetName, baseName)))
+
+	percentLength := stringLength("+100.00%")
+	header := []string{
+		padLeft("BASE", percentLength, ' '),
+		padLeft("TARGET", percentLength, ' '),
+		padLeft("DELTA", percentLength, ' '),
+		"FUNCTION",
+	}
+
+	lines = append(lines, paint(theme.Text, strings.Join(header, " | ")))
+	lines = append(lines, paint(theme.Text, fmt.Sprintf(
+		"%s-+-%s-+-%s-+-%s",
+		padLeft("", percentLength, '-'),
+		padLeft("", percentLength, '-'),
+		padLeft("", percentLength, '-'),
+		padLeft("", stringLength("FUNCTION"), '-'))))
+
+	for _, delta := range deltas {
+		color := theme.Text
+		if delta.Delta() > 0 {
+			color = theme.Warning
+		}
+
+		lines = append(lines, paint(color, fmt.Sprintf(
+			"%s | %s | %s | %s",
+			padLeft(fmt.Sprintf("%0.2f%%", delta.BasePercent), percentLength, ' '),
+			padLeft(fmt.Sprintf("%0.2f%%", delta.TargetPercent), percentLength, ' '),
+			padLeft(fmt.Sprintf("%+0.2f%%", delta.Delta()), percentLength, ' '),
+			delta.Name)))
+	}
+
+	return lines
+}
@@ -0,0 +1,20 @@
+package rendering
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/smarty/benchy/stats"
+)
+
+// ProgressLine renders the progress of a benchmark on a single line, such as
+// "fib: sample 12/100, median 1.234 µs, ETA 1m20s".
+func ProgressLine(name string, sample int, sampleCount int, median stats.Duration, remaining time.Duration) string {
+	return fmt.Sprintf(
+		"%s: sample %d/%d, median %s, ETA %s",
+		name,
+		sample,
+		sampleCount,
+		renderTrimmed(median),
+		remaining.Round(time.Second))
+}
@@ -0,0 +1,16 @@
+package rendering
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProgressLine(t *testing.T) {
+	expected := "fib: sample 12/100, median 1.234 µs, ETA 1m20s"
+
+	actual := ProgressLine("fib", 12, 100, 1_234, 80*time.Second+300*time.Millisecond)
+
+	if actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
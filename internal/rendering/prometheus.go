@@ -0,0 +1,103 @@
+package rendering
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/smarty/benchy/stats"
+)
+
+// prometheusFamily is a single metric with the samples of every benchmark.
+type prometheusFamily struct {
+	name    string
+	kind    string
+	help    string
+	samples []string
+}
+
+func (this *prometheusFamily) add(suffix string, labels string, value float64) {
+	this.samples = append(this.samples, fmt.Sprintf("%s%s{%s} %s", this.name, suffix, labels, formatPrometheusValue(value)))
+}
+
+// PrometheusMetrics renders the results in the Prometheus text exposition
+// format. Durations are summaries in seconds with the quartiles as quantiles,
+// and the other statistics are gauges. Every sample is labelled with the name
+// of the benchmark and the environment of the run in `metadata`.
+func PrometheusMetrics(results []*stats.BenchmarkResult, metadata ReportMetadata) []string {
+	duration := &prometheusFamily{name: "benchy_duration_seconds", kind: "summary", help: "Duration of a single operation, excluding outliers."}
+	deviation := &prometheusFamily{name: "benchy_duration_standard_deviation_seconds", kind: "gauge", help: "Standard deviation of the duration of an operation."}
+	outliers := &prometheusFamily{name: "benchy_outliers", kind: "gauge", help: "Number of samples that were removed as outliers."}
+	allocations := &prometheusFamily{name: "benchy_allocations_per_op", kind: "gauge", help: "Average number of allocations per operation."}
+	growth := &prometheusFamily{name: "benchy_memory_growth_per_op", kind: "gauge", help: "Average number of allocations per operation that are still held."}
+	bytes := &prometheusFamily{name: "benchy_throughput_bytes_per_second", kind: "gauge", help: "Average number of bytes processed per second."}
+	items := &prometheusFamily{name: "benchy_throughput_items_per_second", kind: "gauge", help: "Average number of items processed per second."}
+	metrics := &prometheusFamily{name: "benchy_metric", kind: "gauge", help: "Average of a custom metric per operation."}
+
+	for _, result := range results {
+		labels := prometheusLabels(
+			"benchmark", result.Name,
+			"go_version", metadata.GoVersion,
+			"goos", metadata.OS,
+			"goarch", metadata.Architecture)
+		count := len(result.Samples) - len(result.Outliers)
+
+		duration.add("", labels+`,quantile="0.25"`, toSeconds(result.Quartile1))
+		duration.add("", labels+`,quantile="0.5"`, toSeconds(result.Median))
+		duration.add("", labels+`,quantile="0.75"`, toSeconds(result.Quartile3))
+		duration.add("_sum", labels, toSeconds(result.Average)*float64(count))
+		duration.add("_count", labels, float64(count))
+		deviation.add("", labels, toSeconds(result.StandardDeviation))
+		outliers.add("", labels, float64(len(result.Outliers)))
+		allocations.add("", labels, result.Allocations)
+		growth.add("", labels, result.MemoryGrowth)
+		if result.BytesPerOp > 0 {
+			bytes.add("", labels, float64(result.ByteThroughput.Average))
+		}
+
+		if result.ItemsPerOp > 0 {
+			items.add("", labels, float64(result.ItemThroughput.Average))
+		}
+
+		for _, metric := range result.Metrics {
+			metrics.add("", labels+","+prometheusLabels("name", metric.Name, "unit", metric.Unit), metric.Average)
+		}
+	}
+
+	lines := make([]string, 0)
+	for _, family := range []*prometheusFamily{duration, deviation, outliers, allocations, growth, bytes, items, metrics} {
+		if len(family.samples) == 0 {
+			continue
+		}
+
+		lines = append(lines,
+			fmt.Sprintf("# HELP %s %s", family.name, family.help),
+			fmt.Sprintf("# TYPE %s %s", family.name, family.kind))
+		lines = append(lines, family.samples...)
+	}
+
+	return lines
+}
+
+// prometheusLabels renders pairs of label names and values, escaping the
+// values.
+func prometheusLabels(pairs ...string) string {
+	labels := make([]string, 0, len(pairs)/2)
+	for iPair := 0; iPair+1 < len(pairs); iPair += 2 {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, pairs[iPair], escapeLabelValue(pairs[iPair+1])))
+	}
+
+	return strings.Join(labels, ",")
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatPrometheusValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func toSeconds(duration stats.Duration) float64 {
+	return float64(duration) / 1e9
+}
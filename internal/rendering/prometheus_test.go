@@ -0,0 +1,53 @@
+package rendering
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/smarty/benchy/stats"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	results := []*stats.BenchmarkResult{{
+		Name:      `say "hi"`,
+		Samples:   []stats.Duration{1_000, 2_000, 3_000, 9_000},
+		Outliers:  []stats.Duration{9_000},
+		Average:   2_000,
+		Median:    2_000,
+		Quartile1: 1_500,
+		Quartile3: 2_500,
+		Metrics:   []stats.Metric{{Name: "hits", Unit: "ratio", Average: 0.5}},
+	}}
+	metadata := ReportMetadata{GoVersion: "go1.23", OS: "linux", Architecture: "amd64"}
+
+	actual := PrometheusMetrics(results, metadata)
+
+	labels := `benchmark="say \"hi\"",go_version="go1.23",goos="linux",goarch="amd64"`
+	expected := []string{
+		"# HELP benchy_duration_seconds Duration of a single operation, excluding outliers.",
+		"# TYPE benchy_duration_seconds summary",
+		"benchy_duration_seconds{" + labels + `,quantile="0.25"} 1.5e-06`,
+		"benchy_duration_seconds{" + labels + `,quantile="0.5"} 2e-06`,
+		"benchy_duration_seconds{" + labels + `,quantile="0.75"} 2.5e-06`,
+		"benchy_duration_seconds_sum{" + labels + "} 6e-06",
+		"benchy_duration_seconds_count{" + labels + "} 3",
+		"# HELP benchy_duration_standard_deviation_seconds Standard deviation of the duration of an operation.",
+		"# TYPE benchy_duration_standard_deviation_seconds gauge",
+		"benchy_duration_standard_deviation_seconds{" + labels + "} 0",
+		"# HELP benchy_outliers Number of samples that were removed as outliers.",
+		"# TYPE benchy_outliers gauge",
+		"benchy_outliers{" + labels + "} 1",
+		"# HELP benchy_allocations_per_op Average number of allocations per operation.",
+		"# TYPE benchy_allocations_per_op gauge",
+		"benchy_allocations_per_op{" + labels + "} 0",
+		"# HELP benchy_memory_growth_per_op Average number of allocations per operation that are still held.",
+		"# TYPE benchy_memory_growth_per_op gauge",
+		"benchy_memory_growth_per_op{" + labels + "} 0",
+		"# HELP benchy_metric Average of a custom metric per operation.",
+		"# TYPE benchy_metric gauge",
+		"benchy_metric{" + labels + `,name="hits",unit="ratio"} 0.5`,
+	}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected:\n%q\nactual:\n%q", expected, actual)
+	}
+}
@@ -4,38 +4,49 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/smarty/benchy/options"
 	"github.com/smarty/benchy/stats"
 )
 
 // ExtraRenderingFunc is a function that is used for rendering extra columns.
 type ExtraRenderingFunc func(*[][]string, []*stats.BenchmarkResult)
 
+// ReportCardSettings changes how the report card is rendered.
+type ReportCardSettings struct {
+	// Theme colors the text.
+	Theme options.Theme
+
+	// Baseline is the name of the result that the other results are compared
+	// to. The comparison adds a "RELATIVE" column and the change from the
+	// baseline to every duration. No comparison is made when it is empty.
+	Baseline string
+
+	// FastestBaseline compares the results to the result with the lowest
+	// average, instead of the Baseline.
+	FastestBaseline bool
+
+	// Ranked sorts the results by RankBy, lowest first. Otherwise, the results
+	// are shown in the order they were registered.
+	Ranked bool
+
+	// RankBy is the statistic used to sort the results when Ranked is set.
+	RankBy options.Statistic
+
+	// Columns are added after all other columns, in order.
+	Columns []CustomColumn
+}
+
 // ReportCard renders the report-card as a series of lines which can be written out.
 //
-// Ansi codes are used to color the text.
-func ReportCard(results []*stats.BenchmarkResult, sampleCount int, memFunc ExtraRenderingFunc) []string {
-	data := make([][]string, 0)
-
-	addBenchmarkNames(&data, results)
-	addColumn(&data, "AVERAGE", results, func(result *stats.BenchmarkResult) stats.Duration { return result.Average })
-	if sampleCount >= stats.MinFullCalculation {
-		addColumn(&data, "MEDIAN", results, func(result *stats.BenchmarkResult) stats.Duration { return result.Median })
-		addColumn(&data, "MIN", results, func(result *stats.BenchmarkResult) stats.Duration { return result.Min })
-		addColumn(&data, "MAX", results, func(result *stats.BenchmarkResult) stats.Duration { return result.Max })
-		addColumn(&data, "STD DEV", results, func(result *stats.BenchmarkResult) stats.Duration { return result.StandardDeviation })
-		addColumn(&data, "STD ERR", results, func(result *stats.BenchmarkResult) stats.Duration { return result.StandardError })
-		addColumn(&data, "4σ", results, func(result *stats.BenchmarkResult) stats.Duration { return result.FourSigma })
-		if memFunc != nil {
-			memFunc(&data, results)
-		}
-	}
+// Ansi codes from the theme of the `settings` are used to color the text.
+func ReportCard(results []*stats.BenchmarkResult, sampleCount int, settings ReportCardSettings, extraFuncs ...ExtraRenderingFunc) []string {
+	data := reportCardData(results, sampleCount, settings, extraFuncs)
 
 	// add two lines for the table header
 	lines := make([]string, len(results)+2)
 	sb := strings.Builder{}
 	for iLine := range lines {
 		sb.Reset()
-		sb.WriteString(ansi_blue)
 		for iColumn, column := range data {
 			if iColumn > 0 {
 				// header separator
@@ -49,19 +60,116 @@ func ReportCard(results []*stats.BenchmarkResult, sampleCount int, memFunc Extra
 			sb.WriteString(column[iLine])
 		}
 
-		sb.WriteString(ansi_reset)
-		lines[iLine] = sb.String()
+		lines[iLine] = paint(settings.Theme.Table, sb.String())
 	}
 
 	return lines
 }
 
+// reportCardData builds the columns of the report card. Every column starts
+// with two lines for the table header, followed by a line per result.
+func reportCardData(results []*stats.BenchmarkResult, sampleCount int, settings ReportCardSettings, extraFuncs []ExtraRenderingFunc) [][]string {
+	if settings.Ranked {
+		results = rankResults(results, sampleCount, settings.RankBy)
+	}
+
+	compared := comparison{
+		baseline: findBaseline(results, settings.Baseline, settings.FastestBaseline),
+		theme:    settings.Theme,
+	}
+
+	data := make([][]string, 0)
+
+	addBenchmarkNames(&data, results)
+	if compared.baseline != nil {
+		addRelativeColumn(&data, results, compared)
+	}
+
+	addComparedColumn(&data, "AVERAGE", results, compared, func(result *stats.BenchmarkResult) stats.Duration { return result.Average })
+	if sampleCount >= stats.MinFullCalculation {
+		addComparedColumn(&data, "MEDIAN", results, compared, func(result *stats.BenchmarkResult) stats.Duration { return result.Median })
+		addComparedColumn(&data, "MIN", results, compared, func(result *stats.BenchmarkResult) stats.Duration { return result.Min })
+		addComparedColumn(&data, "MAX", results, compared, func(result *stats.BenchmarkResult) stats.Duration { return result.Max })
+		addComparedColumn(&data, "STD DEV", results, compared, func(result *stats.BenchmarkResult) stats.Duration { return result.StandardDeviation })
+		addComparedColumn(&data, "STD ERR", results, compared, func(result *stats.BenchmarkResult) stats.Duration { return result.StandardError })
+		addComparedColumn(&data, "4σ", results, compared, func(result *stats.BenchmarkResult) stats.Duration { return result.FourSigma })
+		for _, extraFunc := range extraFuncs {
+			if extraFunc != nil {
+				extraFunc(&data, results)
+			}
+		}
+	}
+
+	for _, customColumn := range settings.Columns {
+		addCustomColumn(&data, results, sampleCount, customColumn)
+	}
+
+	return data
+}
+
 // RenderMemoryFunc satisfies the ExtraRenderingFunc interface for rendering memory statistics.
 func RenderMemoryFunc(data *[][]string, results []*stats.BenchmarkResult) {
 	addColumnFloat(data, "ALLOCATIONS", results, func(result *stats.BenchmarkResult) float64 { return result.Allocations })
 	addColumnFloat(data, "MEMORY GROWTH", results, func(result *stats.BenchmarkResult) float64 { return result.MemoryGrowth })
 }
 
+// RenderResourceUsageFunc satisfies the ExtraRenderingFunc interface for rendering resource usage statistics.
+func RenderResourceUsageFunc(data *[][]string, results []*stats.BenchmarkResult) {
+	addColumn(data, "USER TIME", results, func(result *stats.BenchmarkResult) stats.Duration { return result.UserTime })
+	addColumn(data, "SYSTEM TIME", results, func(result *stats.BenchmarkResult) stats.Duration { return result.SystemTime })
+	addColumnFloat(data, "VOL CTX SW", results, func(result *stats.BenchmarkResult) float64 { return result.VoluntaryContextSwitches })
+	addColumnFloat(data, "INVOL CTX SW", results, func(result *stats.BenchmarkResult) float64 { return result.InvoluntaryContextSwitches })
+	addColumnFloat(data, "MINOR FAULTS", results, func(result *stats.BenchmarkResult) float64 { return result.MinorPageFaults })
+	addColumnFloat(data, "MAJOR FAULTS", results, func(result *stats.BenchmarkResult) float64 { return result.MajorPageFaults })
+}
+
+// RenderThroughputFunc satisfies the ExtraRenderingFunc interface for rendering
+// the average byte and item throughput. A column is only added when at least
+// one result has a throughput.
+func RenderThroughputFunc(data *[][]string, results []*stats.BenchmarkResult) {
+	hasBytes := false
+	hasItems := false
+	for _, result := range results {
+		hasBytes = hasBytes || result.BytesPerOp > 0
+		hasItems = hasItems || result.ItemsPerOp > 0
+	}
+
+	if hasBytes {
+		addColumnThroughput(data, "BYTES/S", stats.BytesSuffix, results, func(result *stats.BenchmarkResult) stats.Throughput { return result.ByteThroughput.Average })
+	}
+
+	if hasItems {
+		addColumnThroughput(data, "ITEMS/S", stats.ItemsSuffix, results, func(result *stats.BenchmarkResult) stats.Throughput { return result.ItemThroughput.Average })
+	}
+}
+
+// RenderMetricsFunc satisfies the ExtraRenderingFunc interface for rendering
+// the average of every custom metric. Results without a metric show it as 0.
+func RenderMetricsFunc(data *[][]string, results []*stats.BenchmarkResult) {
+	names := make([]string, 0)
+	units := make(map[string]string)
+	for _, result := range results {
+		for _, metric := range result.Metrics {
+			if _, found := units[metric.Name]; !found {
+				names = append(names, metric.Name)
+				units[metric.Name] = metric.Unit
+			}
+		}
+	}
+
+	for _, name := range names {
+		columnName := strings.ToUpper(name)
+		if units[name] != "" {
+			columnName = fmt.Sprintf("%s (%s)", columnName, units[name])
+		}
+
+		addColumnFloat(data, columnName, results, func(result *stats.BenchmarkResult) float64 {
+			metric, _ := result.FindMetric(name)
+			return metric.Average
+		})
+	}
+}
+
 func addBenchmarkNames(data *[][]string, results []*stats.BenchmarkResult) {
 	nameLength := stringLength("BENCHMARK")
 	for _, result := range results {
@@ -107,6 +215,34 @@ func addColumnFloat(data *[][]string, columnName string, results []*stats.Benchm
 	*data = append(*data, column)
 }
 
+func addColumnThroughput(data *[][]string, columnName string, suffix string, results []*stats.BenchmarkResult, getField func(result *stats.BenchmarkResult) stats.Throughput) {
+	units := make([]string, 0, len(results))
+	for _, result := range results {
+		throughput := getField(result)
+		if throughput > 0 {
+			units = append(units, throughput.Unit())
+		}
+	}
+
+	unit := stats.SmallestThroughputUnit(units...)
+	length := stringLength(columnName)
+	for _, result := range results {
+		throughput := getField(result)
+		length = max(length, throughput.RenderLengthAsUnit(unit, suffix))
+	}
+
+	// add two lines for the table header
+	column := make([]string, len(results)+2)
+	column[0] = padLeft(columnName, length, ' ')
+	column[1] = padLeft("", length, '-')
+	for iResult, result := range results {
+		throughput := getField(result)
+		column[iResult+2] = padLeft(throughput.RenderWithUnit(unit, suffix), length, ' ')
+	}
+
+	*data = append(*data, column)
+}
+
 func calculateRecommendedReportItemLength(columnName string, results []*stats.BenchmarkResult, getField func(result *stats.BenchmarkResult) stats.Duration) (length int, unit string) {
 	units := make([]string, 0, len(results))
 	for _, result := range results {
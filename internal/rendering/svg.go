@@ -0,0 +1,213 @@
+package rendering
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"strings"
+
+	"github.com/smarty/benchy/stats"
+)
+
+const (
+	svgWidth        = 720
+	svgChartHeight  = 200
+	svgRowHeight    = 28
+	svgMarginLeft   = 80
+	svgMarginRight  = 20
+	svgMarginTop    = 10
+	svgMarginBottom = 30
+)
+
+// svgScale maps a range of values onto a range of pixels.
+type svgScale struct {
+	low       float64
+	high      float64
+	pixelLow  float64
+	pixelHigh float64
+}
+
+func newSVGScale(low float64, high float64, pixelLow float64, pixelHigh float64) svgScale {
+	if high <= low {
+		high = low + 1
+	}
+
+	return svgScale{low: low, high: high, pixelLow: pixelLow, pixelHigh: pixelHigh}
+}
+
+func (this svgScale) pixel(value float64) float64 {
+	return this.pixelLow + (value-this.low)/(this.high-this.low)*(this.pixelHigh-this.pixelLow)
+}
+
+// svgBuilder writes the elements of a single svg image.
+type svgBuilder struct {
+	sb     strings.Builder
+	height float64
+}
+
+func newSVGBuilder(height float64) *svgBuilder {
+	builder := &svgBuilder{height: height}
+	builder.sb.WriteString(fmt.Sprintf(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %d %0.0f" width="%d" height="%0.0f" class="chart">`,
+		svgWidth, height, svgWidth, height))
+	return builder
+}
+
+func (this *svgBuilder) rect(x float64, y float64, width float64, height float64, class string) {
+	this.sb.WriteString(fmt.Sprintf(
+		`<rect x="%0.1f" y="%0.1f" width="%0.1f" height="%0.1f" class="%s"/>`,
+		x, y, max(width, 0.5), max(height, 0), class))
+}
+
+func (this *svgBuilder) line(x1 float64, y1 float64, x2 float64, y2 float64, class string) {
+	this.sb.WriteString(fmt.Sprintf(
+		`<line x1="%0.1f" y1="%0.1f" x2="%0.1f" y2="%0.1f" class="%s"/>`,
+		x1, y1, x2, y2, class))
+}
+
+func (this *svgBuilder) circle(x float64, y float64, radius float64, class string, title string) {
+	this.sb.WriteString(fmt.Sprintf(
+		`<circle cx="%0.1f" cy="%0.1f" r="%0.1f" class="%s"><title>%s</title></circle>`,
+		x, y, radius, class, html.EscapeString(title)))
+}
+
+func (this *svgBuilder) text(x float64, y float64, anchor string, value string) {
+	this.sb.WriteString(fmt.Sprintf(
+		`<text x="%0.1f" y="%0.1f" text-anchor="%s">%s</text>`,
+		x, y, anchor, html.EscapeString(value)))
+}
+
+// durationAxis draws a horizontal axis along the bottom, labelled with the
+// lowest, middle and highest duration.
+func (this *svgBuilder) durationAxis(scale svgScale) {
+	y := this.height - svgMarginBottom
+	this.line(scale.pixelLow, y, scale.pixelHigh, y, "axis")
+	middle := (scale.low + scale.high) / 2
+	for _, value := range []float64{scale.low, middle, scale.high} {
+		x := scale.pixel(value)
+		this.line(x, y, x, y+4, "axis")
+		this.text(x, y+18, "middle", renderTrimmed(stats.Duration(value)))
+	}
+}
+
+func (this *svgBuilder) build() template.HTML {
+	this.sb.WriteString("</svg>")
+	return template.HTML(this.sb.String())
+}
+
+// svgHistogram draws the histogram of a result as bars, with the share of
+// outliers in every bucket highlighted.
+func svgHistogram(result *stats.BenchmarkResult) template.HTML {
+	buckets := linearBuckets(*result)
+	builder := newSVGBuilder(svgChartHeight)
+	if len(buckets) == 0 {
+		return builder.build()
+	}
+
+	highest := 0
+	for _, bucket := range buckets {
+		highest = max(highest, bucket.count)
+	}
+
+	bottom := float64(svgChartHeight - svgMarginBottom)
+	xScale := newSVGScale(float64(buckets[0].low), float64(buckets[len(buckets)-1].high), svgMarginLeft, svgWidth-svgMarginRight)
+	yScale := newSVGScale(0, float64(highest), bottom, svgMarginTop)
+	for _, bucket := range buckets {
+		x := xScale.pixel(float64(bucket.low))
+		width := xScale.pixel(float64(bucket.high)) - x - 1
+		top := yScale.pixel(float64(bucket.count))
+		outlierTop := yScale.pixel(float64(min(bucket.outliers, bucket.count)))
+		builder.rect(x, top, width, bottom-top, "bar")
+		builder.rect(x, outlierTop, width, bottom-outlierTop, "outlier")
+	}
+
+	builder.line(svgMarginLeft, bottom, svgMarginLeft, svgMarginTop, "axis")
+	builder.text(svgMarginLeft-6, svgMarginTop+10, "end", fmt.Sprint(highest))
+	builder.text(svgMarginLeft-6, bottom, "end", "0")
+	builder.durationAxis(xScale)
+	return builder.build()
+}
+
+// svgBoxPlot draws the samples of all `results` as box plots on a shared time
+// axis, with a circle for every outlier.
+func svgBoxPlot(results []*stats.BenchmarkResult) template.HTML {
+	height := float64(svgMarginTop + svgMarginBottom + svgRowHeight*len(results))
+	builder := newSVGBuilder(height)
+	axis := newPlotAxis(results, 1)
+	xScale := newSVGScale(float64(axis.low), float64(axis.high), svgMarginLeft, svgWidth-svgMarginRight)
+	for iResult, result := range results {
+		middle := float64(svgMarginTop + svgRowHeight*iResult + svgRowHeight/2)
+		builder.text(svgMarginLeft-6, middle+4, "end", result.Name)
+		if len(result.Samples) == 0 {
+			continue
+		}
+
+		whiskerLow, whiskerHigh := coreRange(result)
+		box := float64(svgRowHeight) / 2
+		builder.line(xScale.pixel(float64(whiskerLow)), middle, xScale.pixel(float64(whiskerHigh)), middle, "whisker")
+		builder.line(xScale.pixel(float64(whiskerLow)), middle-box/2, xScale.pixel(float64(whiskerLow)), middle+box/2, "whisker")
+		builder.line(xScale.pixel(float64(whiskerHigh)), middle-box/2, xScale.pixel(float64(whiskerHigh)), middle+box/2, "whisker")
+		builder.rect(
+			xScale.pixel(float64(result.Quartile1)),
+			middle-box/2,
+			xScale.pixel(float64(result.Quartile3))-xScale.pixel(float64(result.Quartile1)),
+			box,
+			"box")
+		builder.line(xScale.pixel(float64(result.Median)), middle-box/2, xScale.pixel(float64(result.Median)), middle+box/2, "median")
+		for _, outlier := range result.Outliers {
+			builder.circle(xScale.pixel(float64(outlier)), middle, 3, "outlier", renderTrimmed(outlier))
+		}
+	}
+
+	builder.durationAxis(xScale)
+	return builder.build()
+}
+
+// svgScatter draws every sample of a result in the order it was taken, which
+// shows drift over the course of a run. The median is drawn as a dashed line.
+func svgScatter(result *stats.BenchmarkResult) template.HTML {
+	builder := newSVGBuilder(svgChartHeight)
+	if len(result.Samples) == 0 {
+		return builder.build()
+	}
+
+	low, high := result.Samples[0], result.Samples[0]
+	for _, sample := range result.Samples {
+		low = min(low, sample)
+		high = max(high, sample)
+	}
+
+	bottom := float64(svgChartHeight - svgMarginBottom)
+	xScale := newSVGScale(0, float64(max(1, len(result.Samples)-1)), svgMarginLeft, svgWidth-svgMarginRight)
+	yScale := newSVGScale(float64(low), float64(high), bottom, svgMarginTop)
+
+	outliers := make(map[stats.Duration]int)
+	for _, outlier := range result.Outliers {
+		outliers[outlier]++
+	}
+
+	builder.line(svgMarginLeft, yScale.pixel(float64(result.Median)), svgWidth-svgMarginRight, yScale.pixel(float64(result.Median)), "median dashed")
+	for iSample, sample := range result.Samples {
+		class := "sample"
+		if outliers[sample] > 0 {
+			outliers[sample]--
+			class = "outlier"
+		}
+
+		builder.circle(xScale.pixel(float64(iSample)), yScale.pixel(float64(sample)), 3, class,
+			fmt.Sprintf("sample %d: %s", iSample+1, renderTrimmed(sample)))
+	}
+
+	builder.line(svgMarginLeft, bottom, svgWidth-svgMarginRight, bottom, "axis")
+	builder.line(svgMarginLeft, bottom, svgMarginLeft, svgMarginTop, "axis")
+	builder.text(svgMarginLeft-6, svgMarginTop+10, "end", renderTrimmed(high))
+	builder.text(svgMarginLeft-6, bottom, "end", renderTrimmed(low))
+	builder.text(svgMarginLeft, bottom+18, "start", "sample 1")
+	builder.text(svgWidth-svgMarginRight, bottom+18, "end", fmt.Sprintf("sample %d", len(result.Samples)))
+	return builder.build()
+}
+
+// renderTrimmed renders a duration without the padding used to align columns.
+func renderTrimmed(duration stats.Duration) string {
+	return strings.TrimSpace(duration.Render())
+}
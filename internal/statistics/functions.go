@@ -2,6 +2,7 @@ package statistics
 
 import (
 	"math"
+	"slices"
 	"sort"
 )
 
@@ -22,10 +23,11 @@ func Average[T ~float64](collection []T) T {
 	return total / T(len(collection))
 }
 
-// Median finds the median from the `collection`.
+// Median finds the median from the `collection`, which keeps its order.
 func Median[T ~float64](collection []T) T {
-	Sort(collection)
-	return collection[len(collection)/2]
+	sorted := slices.Clone(collection)
+	Sort(sorted)
+	return sorted[len(sorted)/2]
 }
 
 // MinMax finds both the minimum and maximum of the `collection`.
@@ -121,7 +123,12 @@ func Histogram[T ~float64](collection []T) []int {
 	histogram := make([]int, bins)
 	interval := (high - low) / T(bins)
 	for _, value := range collection {
-		index := int((value - low) / interval)
+		// equal values all fall into the first bin
+		index := 0
+		if interval > 0 {
+			index = int((value - low) / interval)
+		}
+
 		if index >= bins {
 			index = bins - 1
 		}
@@ -136,7 +143,8 @@ func Histogram[T ~float64](collection []T) []int {
 func FreedmanDiaconisBins[T ~float64](collection []T) int {
 	iqr := InterQuartileRange(collection)
 	binSize := 2 * iqr / T(math.Cbrt(float64(len(collection))))
-	dataRange := collection[len(collection)-1] - collection[0]
+	minimum, maximum := MinMax(collection)
+	dataRange := maximum - minimum
 
 	// in some unfortunate cases, the calculated number of bins dips below 2,
 	// we ensure that the returned value never does.
@@ -149,6 +157,20 @@ func InterQuartileRange[T ~float64](collection []T) T {
 	return quartile3 - quartile1
 }
 
+// Quartiles finds the first and third quartile of the `collection`.
+func Quartiles[T ~float64](collection []T) (quartile1 T, quartile3 T) {
+	switch len(collection) {
+	case 0:
+		return 0, 0
+
+	case 1:
+		return collection[0], collection[0]
+
+	default:
+		return quartiles1and3(collection)
+	}
+}
+
 // FourSigma calculates the 4th sigma based on the input `samples`.
 func FourSigma[T ~float64](samples []T, standardDeviation T) T {
 	fourX := 4 * standardDeviation
@@ -165,9 +187,10 @@ func FourSigma[T ~float64](samples []T, standardDeviation T) T {
 }
 
 func quartiles1and3[T ~float64](collection []T) (quartile1 T, quartile3 T) {
-	Sort(collection)
-	quartile1 = collection[int(math.Ceil(float64(len(collection))/4))]
-	quartile3 = collection[int(math.Floor((float64(len(collection))*3)/4))]
+	sorted := slices.Clone(collection)
+	Sort(sorted)
+	quartile1 = sorted[int(math.Ceil(float64(len(sorted))/4))]
+	quartile3 = sorted[int(math.Floor((float64(len(sorted))*3)/4))]
 	return quartile1, quartile3
 }
 
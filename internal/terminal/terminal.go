@@ -0,0 +1,30 @@
+// Package terminal detects the properties of the terminal that stats are
+// printed to.
+package terminal
+
+import (
+	"io"
+	"os"
+)
+
+// IsTerminal determines if `writer` is a terminal, rather than a file, a pipe
+// or a log.
+func IsTerminal(writer io.Writer) bool {
+	file, isFile := writer.(*os.File)
+	if !isFile {
+		return false
+	}
+
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// Width finds the number of columns of the terminal that `writer` writes to.
+// Returns 0 when `writer` is not a terminal or its width is unknown.
+func Width(writer io.Writer) int {
+	if !IsTerminal(writer) {
+		return 0
+	}
+
+	return width(writer.(*os.File))
+}
@@ -0,0 +1,30 @@
+//go:build linux
+
+package terminal
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+type windowSize struct {
+	rows    uint16
+	columns uint16
+	xPixels uint16
+	yPixels uint16
+}
+
+func width(file *os.File) int {
+	size := windowSize{}
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		file.Fd(),
+		uintptr(syscall.TIOCGWINSZ),
+		uintptr(unsafe.Pointer(&size)))
+	if errno != 0 {
+		return 0
+	}
+
+	return int(size.columns)
+}
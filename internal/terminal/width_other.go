@@ -0,0 +1,12 @@
+//go:build !linux
+
+package terminal
+
+import (
+	"os"
+)
+
+// width is only detected on Linux.
+func width(file *os.File) int {
+	return 0
+}
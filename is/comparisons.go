@@ -5,7 +5,11 @@ import (
 )
 
 var (
-	FasterThan = assertions.IsFasterThan
-	SlowerThan = assertions.IsSlowerThan
-	NonAllocating = assertions.IsNonAllocating
+	FasterThan        = assertions.IsFasterThan
+	SlowerThan        = assertions.IsSlowerThan
+	NonAllocating     = assertions.IsNonAllocating
+	NotHot            = assertions.IsNotHot
+	MetricAtLeast     = assertions.IsMetricAtLeast
+	MetricAtMost      = assertions.IsMetricAtMost
+	ThroughputAtLeast = assertions.IsThroughputAtLeast
 )
@@ -0,0 +1,57 @@
+package benchy
+
+import (
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/smarty/benchy/internal/rendering"
+	"github.com/smarty/benchy/stats"
+)
+
+// metricsServer serves the results of every benchmark that has finished as a
+// Prometheus /metrics endpoint, while the rest of the run continues.
+type metricsServer struct {
+	mutex    sync.Mutex
+	results  []*stats.BenchmarkResult
+	metadata rendering.ReportMetadata
+	server   *http.Server
+}
+
+// startMetricsServer listens on `address` and serves metrics in the
+// background until closed.
+func startMetricsServer(address string) (*metricsServer, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	this := &metricsServer{metadata: rendering.CurrentMetadata()}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", this.serveMetrics)
+	this.server = &http.Server{Handler: mux}
+	// Serve closes the listener when it returns
+	go func() { _ = this.server.Serve(listener) }()
+
+	return this, nil
+}
+
+// publish adds the result of a finished benchmark to the metrics.
+func (this *metricsServer) publish(result *stats.BenchmarkResult) {
+	this.mutex.Lock()
+	defer this.mutex.Unlock()
+	this.results = append(this.results, result)
+}
+
+func (this *metricsServer) serveMetrics(writer http.ResponseWriter, _ *http.Request) {
+	this.mutex.Lock()
+	lines := rendering.PrometheusMetrics(this.results, this.metadata)
+	this.mutex.Unlock()
+
+	writer.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = writeLines(writer, lines)
+}
+
+func (this *metricsServer) close() {
+	_ = this.server.Close()
+}
@@ -0,0 +1,142 @@
+package benchy
+
+import (
+	"time"
+
+	"github.com/smarty/benchy/internal/benchmark"
+	"github.com/smarty/benchy/stats"
+)
+
+// Observer receives the events of a run as they happen, such as every sample
+// that is taken. Events are delivered in order on the goroutine of the
+// benchmark, between samples, so the time spent observing is not measured but
+// does make the run take longer.
+type Observer interface {
+	// Observe receives a single event, which is one of the event types below,
+	// such as [SampleTaken].
+	Observe(event Event)
+}
+
+// ObserverFunc allows an ordinary function to be used as an [Observer].
+type ObserverFunc func(event Event)
+
+// Observe calls the function with the event.
+func (this ObserverFunc) Observe(event Event) {
+	this(event)
+}
+
+// Event is an event of a run. Use a type switch to find which event it is.
+type Event interface {
+	isEvent()
+}
+
+// BenchmarkStarted is sent before the samples of a benchmark are taken,
+// including those that measure the overhead.
+type BenchmarkStarted struct {
+	// Name is the name of the benchmark.
+	Name string
+
+	// SampleCount is the number of samples that will be taken.
+	SampleCount int
+}
+
+// SampleTaken is sent after every sample of a benchmark.
+type SampleTaken struct {
+	// Name is the name of the benchmark.
+	Name string
+
+	// Sample is the number of samples taken so far, starting at 1.
+	Sample int
+
+	// SampleCount is the number of samples that will be taken.
+	SampleCount int
+
+	// Duration is the duration of a single operation in this sample.
+	Duration stats.Duration
+
+	// Iterations is the number of operations in this sample.
+	Iterations int
+
+	// Allocations is the number of allocations per operation in this sample.
+	Allocations float64
+
+	// MemoryGrowth is the number of allocations per operation in this sample
+	// which were still held after it.
+	MemoryGrowth float64
+
+	// Median is the median of the samples taken so far, including outliers.
+	Median stats.Duration
+
+	// Elapsed is the time spent sampling the benchmark so far.
+	Elapsed time.Duration
+
+	// Overhead is true when the sample measured the overhead of Benchy with
+	// an empty function, which only happens for benchmarks registered with
+	// [options.OverheadSampling].
+	Overhead bool
+}
+
+// Remaining estimates the time left until all samples are taken, from the
+// average time per sample so far.
+func (this SampleTaken) Remaining() time.Duration {
+	return benchmark.Progress{Sample: this.Sample, SampleCount: this.SampleCount, Elapsed: this.Elapsed}.Remaining()
+}
+
+// OverheadMeasured is sent after the overhead of Benchy was measured for a
+// benchmark registered with [options.OverheadSampling], before its samples are
+// taken.
+type OverheadMeasured struct {
+	// Name is the name of the benchmark.
+	Name string
+
+	// Overhead is the average duration of an operation of an empty function,
+	// which is subtracted from every sample.
+	Overhead stats.Duration
+}
+
+// BenchmarkFinished is sent after all samples of a benchmark are taken and its
+// statistics are calculated.
+type BenchmarkFinished struct {
+	// Name is the name of the benchmark.
+	Name string
+
+	// Result contains the statistics of the benchmark.
+	Result *stats.BenchmarkResult
+}
+
+// RunFinished is sent at the end of [Benchy.Run], after the report was
+// printed.
+type RunFinished struct {
+	// Results are the results that are returned by [Benchy.Run].
+	Results *stats.BenchmarkResults
+}
+
+func (BenchmarkStarted) isEvent()  {}
+func (SampleTaken) isEvent()       {}
+func (OverheadMeasured) isEvent()  {}
+func (BenchmarkFinished) isEvent() {}
+func (RunFinished) isEvent()       {}
+
+// observers delivers every event to each of its observers.
+type observers []Observer
+
+func (this observers) Observe(event Event) {
+	for _, observer := range this {
+		observer.Observe(event)
+	}
+}
+
+func newSampleTaken(progress benchmark.Progress) SampleTaken {
+	return SampleTaken{
+		Name:         progress.Name,
+		Sample:       progress.Sample,
+		SampleCount:  progress.SampleCount,
+		Duration:     progress.Duration,
+		Iterations:   progress.Iterations,
+		Allocations:  progress.Allocations,
+		MemoryGrowth: progress.MemoryGrowth,
+		Median:       progress.Median,
+		Elapsed:      progress.Elapsed,
+		Overhead:     progress.Overhead,
+	}
+}
@@ -0,0 +1,109 @@
+package benchy
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/smarty/benchy/options"
+)
+
+var sink int
+
+// setBenchtime sets a fixed number of iterations per sample for the duration
+// of the test, so that sampling does not take seconds.
+func setBenchtime(t *testing.T, benchtime string) {
+	benchtimeFlag := flag.Lookup("test.benchtime")
+	previous := benchtimeFlag.Value.String()
+	if err := benchtimeFlag.Value.Set(benchtime); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = benchtimeFlag.Value.Set(previous) })
+}
+
+// recordEvents runs the benchmarks registered by `register` and records a
+// short description of every event that its observer received.
+func recordEvents(t *testing.T, register func(benchy *Benchy)) []string {
+	setBenchtime(t, "100x")
+	events := make([]string, 0)
+	record := ObserverFunc(func(event Event) {
+		switch event := event.(type) {
+		case BenchmarkStarted:
+			events = append(events, fmt.Sprintf("started %s %d", event.Name, event.SampleCount))
+		case SampleTaken:
+			events = append(events, fmt.Sprintf("sample %s %d/%d overhead=%t", event.Name, event.Sample, event.SampleCount, event.Overhead))
+		case OverheadMeasured:
+			events = append(events, fmt.Sprintf("overhead %s", event.Name))
+		case BenchmarkFinished:
+			events = append(events, fmt.Sprintf("finished %s %s", event.Name, event.Result.Name))
+		case RunFinished:
+			events = append(events, fmt.Sprintf("run finished %d", len(event.Results.Collection)))
+		}
+	})
+
+	testing.Benchmark(func(b *testing.B) {
+		benchy := New(b, options.Fast).DontPrintStats().SetSampleCount(3).AddObserver(record)
+		register(benchy)
+		benchy.Run()
+	})
+
+	return events
+}
+
+func TestRun_ObserverReceivesEventsInOrder(t *testing.T) {
+	events := recordEvents(t, func(benchy *Benchy) {
+		benchy.RegisterBenchmark("add", func() { sink++ }, options.OverheadSampling)
+	})
+
+	expected := []string{
+		"started add 3",
+		"sample add 1/3 overhead=true",
+		"sample add 2/3 overhead=true",
+		"sample add 3/3 overhead=true",
+		"overhead add",
+		"sample add 1/3 overhead=false",
+		"sample add 2/3 overhead=false",
+		"sample add 3/3 overhead=false",
+		"finished add add",
+		"run finished 1",
+	}
+	if !reflect.DeepEqual(expected, events) {
+		t.Errorf("expected:\n%q\nactual:\n%q", expected, events)
+	}
+}
+
+func TestRun_SkippedLongBenchmarkSendsNoEvents(t *testing.T) {
+	events := recordEvents(t, func(benchy *Benchy) {
+		benchy.runningLong = false
+		benchy.RegisterBenchmark("long", func() { sink++ }, options.Long)
+		benchy.RegisterBenchmark("short", func() { sink++ })
+	})
+
+	expected := []string{
+		"started short 3",
+		"sample short 1/3 overhead=false",
+		"sample short 2/3 overhead=false",
+		"sample short 3/3 overhead=false",
+		"finished short short",
+		"run finished 1",
+	}
+	if !reflect.DeepEqual(expected, events) {
+		t.Errorf("expected:\n%q\nactual:\n%q", expected, events)
+	}
+}
+
+func TestRun_WithoutObserversDoesNotReportProgress(t *testing.T) {
+	setBenchtime(t, "100x")
+	var benchy *Benchy
+	testing.Benchmark(func(b *testing.B) {
+		benchy = New(b, options.Fast).DontPrintStats().SetSampleCount(3)
+		benchy.RegisterBenchmark("add", func() { sink++ })
+		benchy.Run()
+	})
+
+	if benchy.benchmarks[0].Progress != nil {
+		t.Error("expected no progress to be reported without observers")
+	}
+}
@@ -19,8 +19,28 @@ const (
 	// fail rather than try to take over the PProf that is already running.
 	//
 	// When turned on, PProf files will be saved in a folder call "workspace" in
-	// the current working directory.
+	// the current working directory, unless another profile directory is set.
+	// The profiles of all samples are merged into a single "cpu_merged.pprof"
+	// file.
 	PProfCPU
+
+	// PProfCPUSamples additionally saves the CPU profile of every sample to its
+	// own "cpu_<n>.pprof" file next to the merged profile. It has no effect
+	// unless PProfCPU is also set.
+	//
+	// Default is off.
+	PProfCPUSamples
+
+	// Trace records an execution trace of every sample, which can be inspected
+	// with `go tool trace`. Each sample is a task, with regions for the setup,
+	// the batch of measured iterations and the cleanup. If a trace is already
+	// being recorded from a higher level (for example, from the CLI), then the
+	// benchmark will fail rather than try to take over that trace.
+	//
+	// When turned on, "trace_<n>.out" files are saved next to the PProf files.
+	//
+	// Default is off.
+	Trace
 )
 
 // Contains determines if all the indicated flags are set in this flags value.
@@ -0,0 +1,16 @@
+package options
+
+// ColorMode decides when stats are printed in color.
+type ColorMode int
+
+const (
+	// ColorAuto prints in color when the output is a terminal and the
+	// `NO_COLOR` environment variable is not set.
+	ColorAuto ColorMode = iota
+
+	// ColorAlways prints in color, even when the output is not a terminal.
+	ColorAlways
+
+	// ColorNever prints without color.
+	ColorNever
+)
@@ -0,0 +1,34 @@
+package options
+
+// Alignment is the side of a report card column that values are aligned to.
+type Alignment int
+
+const (
+	// AlignRight aligns values to the right, which suits numbers.
+	AlignRight Alignment = iota
+
+	// AlignLeft aligns values to the left, which suits text.
+	AlignLeft
+)
+
+// ColumnFormat describes how a custom report card column is rendered. The zero
+// value renders every value in its default format, aligned to the right, for
+// any sample count.
+type ColumnFormat struct {
+	// Format renders a single value. When it is nil, values are rendered
+	// according to their type: durations (stats.Duration) and throughputs
+	// (stats.Throughput) share the smallest fitting unit of the column, floats
+	// are rendered with three decimal places and anything else as with
+	// fmt.Sprint. A nil value is rendered as "-".
+	Format func(value any) string
+
+	// Alignment is the side that values are aligned to.
+	Alignment Alignment
+
+	// MinSampleCount hides the column when fewer samples are taken, for
+	// example stats.MinFullCalculation for values that need full statistics.
+	MinSampleCount int
+
+	// HideWhenZero hides the column when every value is zero or nil.
+	HideWhenZero bool
+}
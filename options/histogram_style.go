@@ -0,0 +1,12 @@
+package options
+
+// HistogramStyle describes how the histogram of every benchmark is drawn. The
+// zero value draws horizontal bars for buckets of equal width.
+type HistogramStyle struct {
+	// LogScale makes every bucket a fixed factor wider than the previous one,
+	// which keeps the short samples readable in heavy-tailed distributions.
+	LogScale bool
+
+	// Vertical draws the bars upwards, with the time axis along the bottom.
+	Vertical bool
+}
@@ -0,0 +1,23 @@
+package options
+
+// OutputFormat selects how results are written to an output.
+type OutputFormat int
+
+const (
+	// TextFormat is the human-readable histograms and report card, as printed
+	// to the terminal.
+	TextFormat OutputFormat = iota
+
+	// JSONFormat is a machine-readable JSON array of every benchmark result
+	// (See stats.BenchmarkResult), written once all benchmarks have run.
+	JSONFormat
+
+	// HTMLFormat is a self-contained HTML page with the report card and SVG
+	// charts of every benchmark, written once all benchmarks have run.
+	HTMLFormat
+
+	// MarkdownFormat is the report card as a Markdown table, followed by a
+	// collapsible section for every benchmark, written once all benchmarks
+	// have run. It is meant to be posted as a pull request comment.
+	MarkdownFormat
+)
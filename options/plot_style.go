@@ -0,0 +1,16 @@
+package options
+
+// PlotStyle selects how the distributions of all benchmarks are drawn on a
+// shared time axis.
+type PlotStyle int
+
+const (
+	// BoxPlot draws the whiskers from the shortest to the longest sample, a box
+	// from the first to the third quartile with the median inside it, and a
+	// marker for every outlier.
+	BoxPlot PlotStyle = iota
+
+	// ViolinPlot draws the density of the samples with block characters, where
+	// higher blocks mean more samples.
+	ViolinPlot
+)
@@ -0,0 +1,21 @@
+package options
+
+// Statistic selects one of the duration statistics of a benchmark result.
+type Statistic int
+
+const (
+	// Average is the mean duration of an operation.
+	Average Statistic = iota
+
+	// Median is the median duration of an operation. It needs at least
+	// stats.MinFullCalculation samples, otherwise the average is used.
+	Median
+
+	// Min is the duration of an operation in the fastest sample. It needs at
+	// least stats.MinFullCalculation samples, otherwise the average is used.
+	Min
+
+	// Max is the duration of an operation in the slowest sample. It needs at
+	// least stats.MinFullCalculation samples, otherwise the average is used.
+	Max
+)
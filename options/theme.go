@@ -0,0 +1,71 @@
+package options
+
+// Color is an ANSI escape sequence that changes how terminal text looks.
+// Colors can be combined by concatenating them, for example `Bold + Yellow`.
+// The empty Color leaves the text as it is.
+type Color string
+
+const (
+	Bold    Color = "\033[1m"
+	Red     Color = "\033[91m"
+	Green   Color = "\033[92m"
+	Yellow  Color = "\033[93m"
+	Blue    Color = "\033[94m"
+	Magenta Color = "\033[95m"
+	Cyan    Color = "\033[96m"
+	White   Color = "\033[97m"
+)
+
+// Theme defines the colors used to print the stats. A field left empty prints
+// that part of the stats without color.
+type Theme struct {
+	// Text is used for histograms, hot functions and profile comparisons.
+	Text Color
+
+	// Table is used for the report card.
+	Table Color
+
+	// Outlier is used for the outliers in histograms.
+	Outlier Color
+
+	// Warning is used for modality warnings and for functions which gained a
+	// share of CPU time in profile comparisons.
+	Warning Color
+
+	// Better is used for benchmarks that beat the baseline in the report card.
+	Better Color
+
+	// Worse is used for benchmarks that fall behind the baseline in the report
+	// card.
+	Worse Color
+}
+
+// DefaultTheme is the theme used unless another theme is set.
+func DefaultTheme() Theme {
+	return Theme{
+		Text:    Cyan,
+		Table:   Blue,
+		Outlier: Yellow,
+		Warning: Yellow,
+		Better:  Green,
+		Worse:   Red,
+	}
+}
+
+// HighContrastTheme uses bright white text, with bold colors to make outliers
+// and warnings stand out.
+func HighContrastTheme() Theme {
+	return Theme{
+		Text:    White,
+		Table:   White,
+		Outlier: Bold + Magenta,
+		Warning: Bold + Yellow,
+		Better:  Bold + Green,
+		Worse:   Bold + Red,
+	}
+}
+
+// NoColorTheme prints the stats without any color.
+func NoColorTheme() Theme {
+	return Theme{}
+}
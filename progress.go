@@ -0,0 +1,80 @@
+package benchy
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/smarty/benchy/internal/rendering"
+	"github.com/smarty/benchy/internal/terminal"
+)
+
+// progressLogInterval is the least time between two lines of progress when
+// the progress is not written to a terminal.
+const progressLogInterval = 10 * time.Second
+
+// newProgressPrinter observes the samples of the benchmarks and shows their
+// progress. A single line is updated when `writer` is a terminal, and
+// otherwise a line is written every so often.
+func newProgressPrinter(writer io.Writer) Observer {
+	if terminal.IsTerminal(writer) {
+		return &terminalProgress{writer: writer, width: terminal.Width(writer)}
+	}
+
+	return &logProgress{writer: writer, interval: progressLogInterval, now: time.Now}
+}
+
+// terminalProgress rewrites a single line of the terminal after every sample,
+// and moves on to the next line when a benchmark is done.
+type terminalProgress struct {
+	writer io.Writer
+	width  int
+}
+
+func (this *terminalProgress) Observe(event Event) {
+	progress, isSample := event.(SampleTaken)
+	if !isSample || progress.Overhead {
+		return
+	}
+
+	line := progressLine(progress)
+	if this.width > 0 && len([]rune(line)) >= this.width {
+		// a line that wraps cannot be rewritten
+		line = string([]rune(line)[:this.width-1])
+	}
+
+	// return to the start of the line and clear the rest of it
+	_, _ = fmt.Fprintf(this.writer, "\r%s\x1b[K", line)
+	if progress.Sample >= progress.SampleCount {
+		_, _ = io.WriteString(this.writer, "\n")
+	}
+}
+
+// logProgress writes a line of progress when a benchmark starts and is done,
+// and at most every interval in between.
+type logProgress struct {
+	writer   io.Writer
+	interval time.Duration
+	now      func() time.Time
+	last     time.Time
+}
+
+func (this *logProgress) Observe(event Event) {
+	progress, isSample := event.(SampleTaken)
+	if !isSample || progress.Overhead {
+		return
+	}
+
+	now := this.now()
+	isFirstOrLast := progress.Sample == 1 || progress.Sample >= progress.SampleCount
+	if !isFirstOrLast && now.Sub(this.last) < this.interval {
+		return
+	}
+
+	this.last = now
+	_, _ = fmt.Fprintln(this.writer, progressLine(progress))
+}
+
+func progressLine(progress SampleTaken) string {
+	return rendering.ProgressLine(progress.Name, progress.Sample, progress.SampleCount, progress.Median, progress.Remaining())
+}
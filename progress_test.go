@@ -0,0 +1,77 @@
+package benchy
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogProgress_ThrottlesBetweenFirstAndLastSample(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	printer := &logProgress{writer: buffer, interval: 10 * time.Second, now: func() time.Time { return clock }}
+
+	// samples arrive every 4 seconds, so at most every third one is printed
+	for sample := 1; sample <= 6; sample++ {
+		printer.Observe(SampleTaken{Name: "fib", Sample: sample, SampleCount: 6})
+		printer.Observe(SampleTaken{Name: "fib", Sample: sample, SampleCount: 6, Overhead: true})
+		clock = clock.Add(4 * time.Second)
+	}
+
+	expected := []string{
+		progressLine(SampleTaken{Name: "fib", Sample: 1, SampleCount: 6}),
+		progressLine(SampleTaken{Name: "fib", Sample: 4, SampleCount: 6}),
+		progressLine(SampleTaken{Name: "fib", Sample: 6, SampleCount: 6}),
+	}
+	actual := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected:\n%q\nactual:\n%q", expected, actual)
+	}
+}
+
+func TestLogProgress_AlwaysPrintsFirstAndLastSample(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	printer := &logProgress{writer: buffer, interval: time.Hour, now: func() time.Time { return clock }}
+
+	for sample := 1; sample <= 3; sample++ {
+		printer.Observe(SampleTaken{Name: "fib", Sample: sample, SampleCount: 3})
+	}
+
+	expected := []string{
+		progressLine(SampleTaken{Name: "fib", Sample: 1, SampleCount: 3}),
+		progressLine(SampleTaken{Name: "fib", Sample: 3, SampleCount: 3}),
+	}
+	actual := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected:\n%q\nactual:\n%q", expected, actual)
+	}
+}
+
+func TestTerminalProgress_TruncatesToWidth(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	printer := &terminalProgress{writer: buffer, width: 20}
+	progress := SampleTaken{Name: "a-rather-long-benchmark-name", Sample: 1, SampleCount: 2}
+
+	printer.Observe(progress)
+
+	expected := "\r" + progressLine(progress)[:19] + "\x1b[K"
+	if actual := buffer.String(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
+
+func TestTerminalProgress_MovesToNextLineAfterLastSample(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	printer := &terminalProgress{writer: buffer, width: 200}
+	progress := SampleTaken{Name: "fib", Sample: 2, SampleCount: 2}
+
+	printer.Observe(progress)
+
+	expected := "\r" + progressLine(progress) + "\x1b[K\n"
+	if actual := buffer.String(); actual != expected {
+		t.Errorf("expected %q, got %q", expected, actual)
+	}
+}
@@ -0,0 +1,231 @@
+package providers
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Distribution decides how likely every row of a provider is to be drawn, by
+// its position. The first rows that were added are the most likely with Zipf
+// and Exponential, so add the hottest rows first.
+type Distribution interface {
+	selector(random *rand.Rand) selector
+}
+
+// Zipf draws the row at index k in proportion to 1/(k+1)^exponent, as with the
+// popularity of keys in a cache. The `exponent` must be greater than 1; larger
+// exponents concentrate the draws on fewer rows.
+func Zipf(exponent float64) Distribution {
+	return zipfDistribution{exponent: max(exponent, math.Nextafter(1, 2))}
+}
+
+// Normal draws rows around the row at `mean` with a `standardDeviation`, both
+// as a fraction of the number of rows. Normal(0.5, 0.1) draws most rows from
+// the middle fifth.
+func Normal(mean float64, standardDeviation float64) Distribution {
+	return normalDistribution{mean: mean, standardDeviation: standardDeviation}
+}
+
+// Exponential draws rows with exponentially decreasing likelihood, where the
+// average index of a row is `mean` as a fraction of the number of rows.
+func Exponential(mean float64) Distribution {
+	return exponentialDistribution{mean: mean}
+}
+
+type zipfDistribution struct {
+	exponent float64
+}
+
+func (this zipfDistribution) selector(random *rand.Rand) selector {
+	return &zipfSelector{random: random, exponent: this.exponent}
+}
+
+// zipfSelector creates the generator again when the number of rows changed.
+type zipfSelector struct {
+	random   *rand.Rand
+	exponent float64
+	zipf     *rand.Zipf
+	count    int
+}
+
+func (this *zipfSelector) next(count int) int {
+	if this.zipf == nil || this.count != count {
+		this.zipf = rand.NewZipf(this.random, this.exponent, 1, uint64(count-1))
+		this.count = count
+	}
+
+	return int(this.zipf.Uint64())
+}
+
+type normalDistribution struct {
+	mean              float64
+	standardDeviation float64
+}
+
+func (this normalDistribution) selector(random *rand.Rand) selector {
+	return &normalSelector{random: random, mean: this.mean, standardDeviation: this.standardDeviation}
+}
+
+type normalSelector struct {
+	random            *rand.Rand
+	mean              float64
+	standardDeviation float64
+}
+
+func (this *normalSelector) next(count int) int {
+	// draws outside the rows are drawn again, rather than piling up at the
+	// first and last row
+	for range 100 {
+		index := int(math.Round(this.mean*float64(count-1) + this.random.NormFloat64()*this.standardDeviation*float64(count)))
+		if index >= 0 && index < count {
+			return index
+		}
+	}
+
+	return min(count-1, max(0, int(math.Round(this.mean*float64(count-1)))))
+}
+
+type exponentialDistribution struct {
+	mean float64
+}
+
+func (this exponentialDistribution) selector(random *rand.Rand) selector {
+	return &exponentialSelector{random: random, mean: this.mean}
+}
+
+type exponentialSelector struct {
+	random *rand.Rand
+	mean   float64
+}
+
+func (this *exponentialSelector) next(count int) int {
+	// draws past the last row are drawn again
+	for range 100 {
+		index := int(this.random.ExpFloat64() * this.mean * float64(count))
+		if index < count {
+			return index
+		}
+	}
+
+	return 0
+}
+
+// ########## 1 ##########
+
+// DistributionProvider1 stores value tuples which are drawn at random, by the
+// position of each row in a Distribution.
+type DistributionProvider1[T0 any] struct {
+	selectingProvider1[T0]
+	seeded
+}
+
+// NewDistribution1 generates a new provider which draws its rows with the `seed`
+// by their position in the `distribution`, such as Zipf(1.1). A seed of 0
+// picks a random seed, which is printed.
+func NewDistribution1[T0 any](seed uint64, distribution Distribution, target func(T0)) *DistributionProvider1[T0] {
+	provider := new(DistributionProvider1[T0])
+	provider.seed = selectSeed(seed)
+	provider.selector = distribution.selector(newRandom(provider.seed))
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ########## 2 ##########
+
+// DistributionProvider2 stores value tuples which are drawn at random, by the
+// position of each row in a Distribution.
+type DistributionProvider2[T0 any, T1 any] struct {
+	selectingProvider2[T0, T1]
+	seeded
+}
+
+// NewDistribution2 generates a new provider which draws its rows with the `seed`
+// by their position in the `distribution`, such as Zipf(1.1). A seed of 0
+// picks a random seed, which is printed.
+func NewDistribution2[T0 any, T1 any](seed uint64, distribution Distribution, target func(T0, T1)) *DistributionProvider2[T0, T1] {
+	provider := new(DistributionProvider2[T0, T1])
+	provider.seed = selectSeed(seed)
+	provider.selector = distribution.selector(newRandom(provider.seed))
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ########## 3 ##########
+
+// DistributionProvider3 stores value tuples which are drawn at random, by the
+// position of each row in a Distribution.
+type DistributionProvider3[T0 any, T1 any, T2 any] struct {
+	selectingProvider3[T0, T1, T2]
+	seeded
+}
+
+// NewDistribution3 generates a new provider which draws its rows with the `seed`
+// by their position in the `distribution`, such as Zipf(1.1). A seed of 0
+// picks a random seed, which is printed.
+func NewDistribution3[T0 any, T1 any, T2 any](seed uint64, distribution Distribution, target func(T0, T1, T2)) *DistributionProvider3[T0, T1, T2] {
+	provider := new(DistributionProvider3[T0, T1, T2])
+	provider.seed = selectSeed(seed)
+	provider.selector = distribution.selector(newRandom(provider.seed))
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ########## 4 ##########
+
+// DistributionProvider4 stores value tuples which are drawn at random, by the
+// position of each row in a Distribution.
+type DistributionProvider4[T0 any, T1 any, T2 any, T3 any] struct {
+	selectingProvider4[T0, T1, T2, T3]
+	seeded
+}
+
+// NewDistribution4 generates a new provider which draws its rows with the `seed`
+// by their position in the `distribution`, such as Zipf(1.1). A seed of 0
+// picks a random seed, which is printed.
+func NewDistribution4[T0 any, T1 any, T2 any, T3 any](seed uint64, distribution Distribution, target func(T0, T1, T2, T3)) *DistributionProvider4[T0, T1, T2, T3] {
+	provider := new(DistributionProvider4[T0, T1, T2, T3])
+	provider.seed = selectSeed(seed)
+	provider.selector = distribution.selector(newRandom(provider.seed))
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ########## 5 ##########
+
+// DistributionProvider5 stores value tuples which are drawn at random, by the
+// position of each row in a Distribution.
+type DistributionProvider5[T0 any, T1 any, T2 any, T3 any, T4 any] struct {
+	selectingProvider5[T0, T1, T2, T3, T4]
+	seeded
+}
+
+// NewDistribution5 generates a new provider which draws its rows with the `seed`
+// by their position in the `distribution`, such as Zipf(1.1). A seed of 0
+// picks a random seed, which is printed.
+func NewDistribution5[T0 any, T1 any, T2 any, T3 any, T4 any](seed uint64, distribution Distribution, target func(T0, T1, T2, T3, T4)) *DistributionProvider5[T0, T1, T2, T3, T4] {
+	provider := new(DistributionProvider5[T0, T1, T2, T3, T4])
+	provider.seed = selectSeed(seed)
+	provider.selector = distribution.selector(newRandom(provider.seed))
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ########## 6 ##########
+
+// DistributionProvider6 stores value tuples which are drawn at random, by the
+// position of each row in a Distribution.
+type DistributionProvider6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any] struct {
+	selectingProvider6[T0, T1, T2, T3, T4, T5]
+	seeded
+}
+
+// NewDistribution6 generates a new provider which draws its rows with the `seed`
+// by their position in the `distribution`, such as Zipf(1.1). A seed of 0
+// picks a random seed, which is printed.
+func NewDistribution6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any](seed uint64, distribution Distribution, target func(T0, T1, T2, T3, T4, T5)) *DistributionProvider6[T0, T1, T2, T3, T4, T5] {
+	provider := new(DistributionProvider6[T0, T1, T2, T3, T4, T5])
+	provider.seed = selectSeed(seed)
+	provider.selector = distribution.selector(newRandom(provider.seed))
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
@@ -0,0 +1,46 @@
+package providers
+
+import (
+	"testing"
+)
+
+func TestDistributions_DrawWithinRows(t *testing.T) {
+	for name, distribution := range map[string]Distribution{
+		"zipf":        Zipf(1.5),
+		"normal":      Normal(0.5, 0.5),
+		"exponential": Exponential(0.5),
+	} {
+		for _, count := range []int{1, 2, 100} {
+			selector := distribution.selector(newRandom(42))
+			for range 1_000 {
+				if index := selector.next(count); index < 0 || index >= count {
+					t.Fatalf("%s: expected an index below %d, got %d", name, count, index)
+				}
+			}
+		}
+	}
+}
+
+func TestDistributions_FavorTheirMode(t *testing.T) {
+	for name, test := range map[string]struct {
+		distribution Distribution
+		mode         int
+	}{
+		"zipf":        {Zipf(1.5), 0},
+		"normal":      {Normal(0.5, 0.1), 5},
+		"exponential": {Exponential(0.2), 0},
+	} {
+		selector := test.distribution.selector(newRandom(42))
+		counts := make([]int, 11)
+		for range 10_000 {
+			counts[selector.next(11)]++
+		}
+
+		for index, count := range counts {
+			if count > counts[test.mode] {
+				t.Errorf("%s: expected row %d to be drawn most, but row %d was drawn more: %v", name, test.mode, index, counts)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,475 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// ReadJSONL reads every line of the JSONL file at `path` as a row, decoded into
+// T. Empty lines are skipped. The benchmark fails when the file cannot be read,
+// when a line is malformed, or when there are no rows.
+//
+// Tuples can be decoded from arrays, such as `[1, "one"]` for a Tuple2.
+func ReadJSONL[T any](tb testing.TB, path string) []T {
+	tb.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		tb.Fatalf("providers: cannot read rows: %v", err)
+		return nil
+	}
+
+	defer func() { _ = file.Close() }()
+
+	rows := make([]T, 0)
+	scanner := bufio.NewScanner(file)
+	// captured requests can be much longer than the default limit of a line
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var row T
+		if err = json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			tb.Fatalf("providers: %s:%d: malformed row: %v", path, line, err)
+			return nil
+		}
+
+		rows = append(rows, row)
+	}
+
+	if err = scanner.Err(); err != nil {
+		tb.Fatalf("providers: cannot read rows from %s: %v", path, err)
+		return nil
+	}
+
+	return requireRows(tb, path, rows)
+}
+
+// ReadCSV reads every record of the CSV file at `path` after the header as a
+// row. Cells are decoded into the fields of a struct T by the names in the
+// header, which match the `csv` tag of a field or its name in any case.
+// Otherwise, the first cell is decoded into T. The benchmark fails when the
+// file cannot be read, when a cell is malformed, or when there are no rows.
+//
+// Strings are taken as they are, types that implement
+// encoding.TextUnmarshaler decode themselves, and other cells are decoded as
+// JSON, such as numbers and booleans.
+func ReadCSV[T any](tb testing.TB, path string) []T {
+	tb.Helper()
+	header, records := readCSVRecords(tb, path)
+	columns, isStruct := structColumns(reflect.TypeFor[T](), header)
+	if isStruct && !slices.ContainsFunc(columns, func(iField int) bool { return iField >= 0 }) {
+		tb.Fatalf("providers: %s: no column of the header %v matches a field of %s", path, header, reflect.TypeFor[T]())
+		return nil
+	}
+
+	rows := make([]T, 0, len(records))
+	for iRecord, record := range records {
+		var row T
+		target := reflect.ValueOf(&row).Elem()
+		var err error
+		if isStruct {
+			err = decodeStruct(target, columns, record)
+		} else {
+			err = decodeCell(target, record[0])
+		}
+
+		if err != nil {
+			// the header is the first line
+			tb.Fatalf("providers: %s:%d: malformed row: %v", path, iRecord+2, err)
+			return nil
+		}
+
+		rows = append(rows, row)
+	}
+
+	return requireRows(tb, path, rows)
+}
+
+// ReadDir reads every file that matches the glob `pattern`, such as
+// "testdata/*.json", as a row, in the order of their names. A T of []byte or
+// string gets the contents of the file, and other types are decoded from
+// JSON. The benchmark fails when a file cannot be read or decoded, or when no
+// file matches.
+func ReadDir[T any](tb testing.TB, pattern string) []T {
+	tb.Helper()
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		tb.Fatalf("providers: invalid pattern %q: %v", pattern, err)
+		return nil
+	}
+
+	rows := make([]T, 0, len(paths))
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			tb.Fatalf("providers: cannot read row: %v", err)
+			return nil
+		}
+
+		var row T
+		switch target := any(&row).(type) {
+		case *[]byte:
+			*target = contents
+		case *string:
+			*target = string(contents)
+		default:
+			if err = json.Unmarshal(contents, &row); err != nil {
+				tb.Fatalf("providers: %s: malformed row: %v", path, err)
+				return nil
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return requireRows(tb, pattern, rows)
+}
+
+func requireRows[T any](tb testing.TB, source string, rows []T) []T {
+	tb.Helper()
+	if len(rows) == 0 {
+		tb.Fatalf("providers: %s has no rows", source)
+	}
+
+	return rows
+}
+
+// readCSVRecords reads the header and the records of a CSV file, which must all
+// have as many cells as the header.
+func readCSVRecords(tb testing.TB, path string) (header []string, records [][]string) {
+	tb.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		tb.Fatalf("providers: cannot read rows: %v", err)
+		return nil, nil
+	}
+
+	defer func() { _ = file.Close() }()
+
+	reader := csv.NewReader(file)
+	header, err = reader.Read()
+	if errors.Is(err, io.EOF) {
+		tb.Fatalf("providers: %s has no rows", path)
+		return nil, nil
+	}
+
+	if err != nil {
+		tb.Fatalf("providers: %s: malformed header: %v", path, err)
+		return nil, nil
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return header, records
+		}
+
+		if err != nil {
+			tb.Fatalf("providers: %s: malformed row: %v", path, err)
+			return nil, nil
+		}
+
+		records = append(records, record)
+	}
+}
+
+// structColumns finds the index of the field of `rowType` for every column of
+// the `header`, or -1 for columns without a field. It is only used for structs
+// which do not decode themselves.
+func structColumns(rowType reflect.Type, header []string) (columns []int, isStruct bool) {
+	if rowType.Kind() != reflect.Struct || reflect.PointerTo(rowType).Implements(textUnmarshalerType) {
+		return nil, false
+	}
+
+	columns = make([]int, len(header))
+	for iColumn, name := range header {
+		columns[iColumn] = -1
+		for iField := range rowType.NumField() {
+			field := rowType.Field(iField)
+			tag, _, _ := strings.Cut(field.Tag.Get("csv"), ",")
+			if field.IsExported() && (tag == strings.TrimSpace(name) || tag == "" && strings.EqualFold(field.Name, strings.TrimSpace(name))) {
+				columns[iColumn] = iField
+				break
+			}
+		}
+	}
+
+	return columns, true
+}
+
+func decodeStruct(target reflect.Value, columns []int, record []string) error {
+	for iColumn, iField := range columns {
+		if iField < 0 {
+			continue
+		}
+
+		if err := decodeCell(target.Field(iField), record[iColumn]); err != nil {
+			return fmt.Errorf("field %s: %w", target.Type().Field(iField).Name, err)
+		}
+	}
+
+	return nil
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// decodeCell decodes a single cell into `target`, which must be settable.
+func decodeCell(target reflect.Value, cell string) error {
+	if unmarshaler, isUnmarshaler := target.Addr().Interface().(encoding.TextUnmarshaler); isUnmarshaler {
+		return unmarshaler.UnmarshalText([]byte(cell))
+	}
+
+	if target.Kind() == reflect.String {
+		target.SetString(cell)
+		return nil
+	}
+
+	return json.Unmarshal([]byte(cell), target.Addr().Interface())
+}
+
+// decodeRecord decodes the first cells of a record into the `values`.
+func decodeRecord(record []string, values ...any) error {
+	if len(record) < len(values) {
+		return fmt.Errorf("expected %d cells, got %d", len(values), len(record))
+	}
+
+	for iValue, value := range values {
+		if err := decodeCell(reflect.ValueOf(value).Elem(), record[iValue]); err != nil {
+			return fmt.Errorf("cell %d: %w", iValue, err)
+		}
+	}
+
+	return nil
+}
+
+// readCSVTuples reads the records of a CSV file with `decode`, which decodes
+// the first cells into a tuple.
+func readCSVTuples[T any](tb testing.TB, path string, decode func(record []string, row *T) error) []T {
+	tb.Helper()
+	_, records := readCSVRecords(tb, path)
+	rows := make([]T, 0, len(records))
+	for iRecord, record := range records {
+		var row T
+		if err := decode(record, &row); err != nil {
+			tb.Fatalf("providers: %s:%d: malformed row: %v", path, iRecord+2, err)
+			return nil
+		}
+
+		rows = append(rows, row)
+	}
+
+	return requireRows(tb, path, rows)
+}
+
+// ########## 1 ##########
+
+// FromJSONL generates a new ring provider with a row for every line of the
+// JSONL file at `path`, decoded into T. See ReadJSONL for details.
+func FromJSONL[T0 any](tb testing.TB, path string, target func(T0)) *RingProvider1[T0] {
+	tb.Helper()
+	provider := New1(target)
+	for _, row := range ReadJSONL[T0](tb, path) {
+		provider.Add(row)
+	}
+
+	return provider
+}
+
+// FromCSV generates a new ring provider with a row for every record of the CSV
+// file at `path`, decoded into a struct T by the header. See ReadCSV for
+// details.
+func FromCSV[T0 any](tb testing.TB, path string, target func(T0)) *RingProvider1[T0] {
+	tb.Helper()
+	provider := New1(target)
+	for _, row := range ReadCSV[T0](tb, path) {
+		provider.Add(row)
+	}
+
+	return provider
+}
+
+// FromDir generates a new ring provider with a row for every file that matches
+// the glob `pattern`, such as "testdata/*.json". See ReadDir for details.
+func FromDir[T0 any](tb testing.TB, pattern string, target func(T0)) *RingProvider1[T0] {
+	tb.Helper()
+	provider := New1(target)
+	for _, row := range ReadDir[T0](tb, pattern) {
+		provider.Add(row)
+	}
+
+	return provider
+}
+
+// ########## 2 ##########
+
+// FromJSONL2 generates a new ring provider with a row for every line of the
+// JSONL file at `path`, where every line is an array of 2 values. See ReadJSONL
+// for details.
+func FromJSONL2[T0 any, T1 any](tb testing.TB, path string, target func(T0, T1)) *RingProvider2[T0, T1] {
+	tb.Helper()
+	provider := New2(target)
+	for _, row := range ReadJSONL[Tuple2[T0, T1]](tb, path) {
+		provider.Add(row.Value0, row.Value1)
+	}
+
+	return provider
+}
+
+// FromCSV2 generates a new ring provider with a row for every record of the CSV
+// file at `path` after the header, decoded from the first 2 cells. See ReadCSV
+// for how cells are decoded.
+func FromCSV2[T0 any, T1 any](tb testing.TB, path string, target func(T0, T1)) *RingProvider2[T0, T1] {
+	tb.Helper()
+	provider := New2(target)
+	decode := func(record []string, row *Tuple2[T0, T1]) error {
+		return decodeRecord(record, &row.Value0, &row.Value1)
+	}
+
+	for _, row := range readCSVTuples(tb, path, decode) {
+		provider.Add(row.Value0, row.Value1)
+	}
+
+	return provider
+}
+
+// ########## 3 ##########
+
+// FromJSONL3 generates a new ring provider with a row for every line of the
+// JSONL file at `path`, where every line is an array of 3 values. See ReadJSONL
+// for details.
+func FromJSONL3[T0 any, T1 any, T2 any](tb testing.TB, path string, target func(T0, T1, T2)) *RingProvider3[T0, T1, T2] {
+	tb.Helper()
+	provider := New3(target)
+	for _, row := range ReadJSONL[Tuple3[T0, T1, T2]](tb, path) {
+		provider.Add(row.Value0, row.Value1, row.Value2)
+	}
+
+	return provider
+}
+
+// FromCSV3 generates a new ring provider with a row for every record of the CSV
+// file at `path` after the header, decoded from the first 3 cells. See ReadCSV
+// for how cells are decoded.
+func FromCSV3[T0 any, T1 any, T2 any](tb testing.TB, path string, target func(T0, T1, T2)) *RingProvider3[T0, T1, T2] {
+	tb.Helper()
+	provider := New3(target)
+	decode := func(record []string, row *Tuple3[T0, T1, T2]) error {
+		return decodeRecord(record, &row.Value0, &row.Value1, &row.Value2)
+	}
+
+	for _, row := range readCSVTuples(tb, path, decode) {
+		provider.Add(row.Value0, row.Value1, row.Value2)
+	}
+
+	return provider
+}
+
+// ########## 4 ##########
+
+// FromJSONL4 generates a new ring provider with a row for every line of the
+// JSONL file at `path`, where every line is an array of 4 values. See ReadJSONL
+// for details.
+func FromJSONL4[T0 any, T1 any, T2 any, T3 any](tb testing.TB, path string, target func(T0, T1, T2, T3)) *RingProvider4[T0, T1, T2, T3] {
+	tb.Helper()
+	provider := New4(target)
+	for _, row := range ReadJSONL[Tuple4[T0, T1, T2, T3]](tb, path) {
+		provider.Add(row.Value0, row.Value1, row.Value2, row.Value3)
+	}
+
+	return provider
+}
+
+// FromCSV4 generates a new ring provider with a row for every record of the CSV
+// file at `path` after the header, decoded from the first 4 cells. See ReadCSV
+// for how cells are decoded.
+func FromCSV4[T0 any, T1 any, T2 any, T3 any](tb testing.TB, path string, target func(T0, T1, T2, T3)) *RingProvider4[T0, T1, T2, T3] {
+	tb.Helper()
+	provider := New4(target)
+	decode := func(record []string, row *Tuple4[T0, T1, T2, T3]) error {
+		return decodeRecord(record, &row.Value0, &row.Value1, &row.Value2, &row.Value3)
+	}
+
+	for _, row := range readCSVTuples(tb, path, decode) {
+		provider.Add(row.Value0, row.Value1, row.Value2, row.Value3)
+	}
+
+	return provider
+}
+
+// ########## 5 ##########
+
+// FromJSONL5 generates a new ring provider with a row for every line of the
+// JSONL file at `path`, where every line is an array of 5 values. See ReadJSONL
+// for details.
+func FromJSONL5[T0 any, T1 any, T2 any, T3 any, T4 any](tb testing.TB, path string, target func(T0, T1, T2, T3, T4)) *RingProvider5[T0, T1, T2, T3, T4] {
+	tb.Helper()
+	provider := New5(target)
+	for _, row := range ReadJSONL[Tuple5[T0, T1, T2, T3, T4]](tb, path) {
+		provider.Add(row.Value0, row.Value1, row.Value2, row.Value3, row.Value4)
+	}
+
+	return provider
+}
+
+// FromCSV5 generates a new ring provider with a row for every record of the CSV
+// file at `path` after the header, decoded from the first 5 cells. See ReadCSV
+// for how cells are decoded.
+func FromCSV5[T0 any, T1 any, T2 any, T3 any, T4 any](tb testing.TB, path string, target func(T0, T1, T2, T3, T4)) *RingProvider5[T0, T1, T2, T3, T4] {
+	tb.Helper()
+	provider := New5(target)
+	decode := func(record []string, row *Tuple5[T0, T1, T2, T3, T4]) error {
+		return decodeRecord(record, &row.Value0, &row.Value1, &row.Value2, &row.Value3, &row.Value4)
+	}
+
+	for _, row := range readCSVTuples(tb, path, decode) {
+		provider.Add(row.Value0, row.Value1, row.Value2, row.Value3, row.Value4)
+	}
+
+	return provider
+}
+
+// ########## 6 ##########
+
+// FromJSONL6 generates a new ring provider with a row for every line of the
+// JSONL file at `path`, where every line is an array of 6 values. See ReadJSONL
+// for details.
+func FromJSONL6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any](tb testing.TB, path string, target func(T0, T1, T2, T3, T4, T5)) *RingProvider6[T0, T1, T2, T3, T4, T5] {
+	tb.Helper()
+	provider := New6(target)
+	for _, row := range ReadJSONL[Tuple6[T0, T1, T2, T3, T4, T5]](tb, path) {
+		provider.Add(row.Value0, row.Value1, row.Value2, row.Value3, row.Value4, row.Value5)
+	}
+
+	return provider
+}
+
+// FromCSV6 generates a new ring provider with a row for every record of the CSV
+// file at `path` after the header, decoded from the first 6 cells. See ReadCSV
+// for how cells are decoded.
+func FromCSV6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any](tb testing.TB, path string, target func(T0, T1, T2, T3, T4, T5)) *RingProvider6[T0, T1, T2, T3, T4, T5] {
+	tb.Helper()
+	provider := New6(target)
+	decode := func(record []string, row *Tuple6[T0, T1, T2, T3, T4, T5]) error {
+		return decodeRecord(record, &row.Value0, &row.Value1, &row.Value2, &row.Value3, &row.Value4, &row.Value5)
+	}
+
+	for _, row := range readCSVTuples(tb, path, decode) {
+		provider.Add(row.Value0, row.Value1, row.Value2, row.Value3, row.Value4, row.Value5)
+	}
+
+	return provider
+}
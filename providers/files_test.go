@@ -0,0 +1,139 @@
+package providers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type request struct {
+	Method string `csv:"method"`
+	Path   string
+	Size   int
+}
+
+// fatalRecorder records the message of Fatalf, without stopping the test.
+type fatalRecorder struct {
+	testing.TB
+	message string
+}
+
+func (this *fatalRecorder) Helper() {}
+
+func (this *fatalRecorder) Fatalf(format string, args ...any) {
+	this.message = fmt.Sprintf(format, args...)
+}
+
+func writeFile(t *testing.T, name string, contents string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestReadJSONL(t *testing.T) {
+	path := writeFile(t, "requests.jsonl", "{\"Method\":\"GET\",\"Path\":\"/a\"}\n\n{\"Method\":\"POST\",\"Size\":3}\n")
+
+	actual := ReadJSONL[request](t, path)
+
+	expected := []request{{Method: "GET", Path: "/a"}, {Method: "POST", Size: 3}}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestReadJSONL_Tuples(t *testing.T) {
+	path := writeFile(t, "rows.jsonl", "[1, \"one\"]\n{\"Value0\": 2, \"Value1\": \"two\"}\n")
+
+	actual := ReadJSONL[Tuple2[int, string]](t, path)
+
+	expected := []Tuple2[int, string]{{1, "one"}, {2, "two"}}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestReadJSONL_MalformedRow(t *testing.T) {
+	path := writeFile(t, "rows.jsonl", "[1, \"one\"]\n[2]\n")
+	recorder := &fatalRecorder{TB: t}
+
+	ReadJSONL[Tuple2[int, string]](recorder, path)
+
+	if !strings.Contains(recorder.message, "rows.jsonl:2: malformed row: expected an array of 2 values, got 1") {
+		t.Errorf("expected the line of the malformed row, got %q", recorder.message)
+	}
+}
+
+func TestReadCSV_Struct(t *testing.T) {
+	path := writeFile(t, "requests.csv", "method,path,size,unused\nGET,/a,1,x\nPOST,\"/b,c\",2,y\n")
+
+	actual := ReadCSV[request](t, path)
+
+	expected := []request{{Method: "GET", Path: "/a", Size: 1}, {Method: "POST", Path: "/b,c", Size: 2}}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestReadCSV_MalformedCell(t *testing.T) {
+	path := writeFile(t, "requests.csv", "method,size\nGET,1\nPOST,many\n")
+	recorder := &fatalRecorder{TB: t}
+
+	ReadCSV[request](recorder, path)
+
+	if !strings.Contains(recorder.message, "requests.csv:3: malformed row: field Size") {
+		t.Errorf("expected the line and field of the malformed cell, got %q", recorder.message)
+	}
+}
+
+func TestFromCSV2(t *testing.T) {
+	path := writeFile(t, "rows.csv", "key,count\nfirst,1\nsecond,2\n")
+	actual := make([]Tuple2[string, int], 0)
+
+	provider := FromCSV2(t, path, func(key string, count int) {
+		actual = append(actual, Tuple2[string, int]{key, count})
+	})
+	benchmark := provider.BenchmarkFunc()
+	benchmark()
+	benchmark()
+
+	if !slices.Contains(actual, Tuple2[string, int]{"first", 1}) || !slices.Contains(actual, Tuple2[string, int]{"second", 2}) {
+		t.Errorf("expected both rows, got %v", actual)
+	}
+}
+
+func TestReadDir(t *testing.T) {
+	directory := t.TempDir()
+	for name, contents := range map[string]string{"b.json": "2", "a.json": "1", "c.txt": "3"} {
+		if err := os.WriteFile(filepath.Join(directory, name), []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	numbers := ReadDir[int](t, filepath.Join(directory, "*.json"))
+	contents := ReadDir[string](t, filepath.Join(directory, "*"))
+
+	if !reflect.DeepEqual([]int{1, 2}, numbers) {
+		t.Errorf("expected the decoded files in the order of their names, got %v", numbers)
+	}
+
+	if !reflect.DeepEqual([]string{"1", "2", "3"}, contents) {
+		t.Errorf("expected the contents of the files, got %v", contents)
+	}
+}
+
+func TestReadDir_NoRows(t *testing.T) {
+	recorder := &fatalRecorder{TB: t}
+
+	ReadDir[string](recorder, filepath.Join(t.TempDir(), "*.json"))
+
+	if !strings.Contains(recorder.message, "has no rows") {
+		t.Errorf("expected a failure for no rows, got %q", recorder.message)
+	}
+}
@@ -0,0 +1,513 @@
+package providers
+
+import (
+	"iter"
+	"testing"
+)
+
+// generator produces values on demand, optionally from a buffer that is filled
+// ahead of time.
+type generator[T any] struct {
+	generate func(i int) T
+	index    int
+	buffer   []T
+	position int
+	prefetch int
+	untimed  func(function func())
+	refill   func()
+}
+
+// Prefetch fills the buffer with the next values while the sample is not
+// measured, so that the benchmark function takes them from the buffer instead
+// of generating them. When the buffer runs out during the sample, the next
+// values are generated with `untimed` again. Values that were not used are
+// kept. Register the provider with Benchy.RegisterPrefetch, which calls
+// Prefetch before every sample.
+func (this *generator[T]) Prefetch(untimed func(function func())) {
+	if this.prefetch == 0 {
+		return
+	}
+
+	if this.refill == nil {
+		this.refill = this.fill
+	}
+
+	this.untimed = untimed
+	this.untimed(this.refill)
+}
+
+func (this *generator[T]) fill() {
+	remaining := copy(this.buffer[:cap(this.buffer)], this.buffer[this.position:])
+	this.buffer = this.buffer[:remaining]
+	this.position = 0
+	for len(this.buffer) < this.prefetch {
+		this.buffer = append(this.buffer, this.generate(this.index))
+		this.index++
+	}
+}
+
+func (this *generator[T]) setPrefetch(size int) {
+	this.prefetch = max(0, size)
+	if cap(this.buffer) < this.prefetch {
+		buffer := make([]T, len(this.buffer)-this.position, this.prefetch)
+		copy(buffer, this.buffer[this.position:])
+		this.buffer, this.position = buffer, 0
+	}
+}
+
+// next takes the next value from the buffer. When the buffer is empty, it is
+// filled again without being measured, or the value is generated when nothing
+// was prefetched.
+func (this *generator[T]) next() T {
+	if this.position >= len(this.buffer) && this.untimed != nil {
+		this.untimed(this.refill)
+	}
+
+	if this.position < len(this.buffer) {
+		value := this.buffer[this.position]
+		this.position++
+		return value
+	}
+
+	value := this.generate(this.index)
+	this.index++
+	return value
+}
+
+// pull generates the values of `seq` in order, and starts over when they run
+// out. An empty sequence fails `tb` right away, and the sequence is stopped
+// when the test of `tb` is done.
+func pull[T any](tb testing.TB, seq iter.Seq[T]) func(i int) T {
+	tb.Helper()
+	next, stop := iter.Pull(seq)
+	tb.Cleanup(func() { stop() })
+	first, ok := next()
+	if !ok {
+		tb.Fatalf("providers: the sequence of the generator has no values")
+	}
+
+	pending, failed := true, false
+	return func(int) T {
+		if pending {
+			pending = false
+			return first
+		}
+
+		value, ok := next()
+		if ok || failed {
+			return value
+		}
+
+		stop()
+		next, stop = iter.Pull(seq)
+		if value, ok = next(); !ok {
+			// a sequence that can only be iterated once cannot start over
+			tb.Errorf("providers: the sequence of the generator ran out and has no values when started over")
+			failed = true
+		}
+
+		return value
+	}
+}
+
+// pull2 is pull for sequences of pairs.
+func pull2[T0 any, T1 any](tb testing.TB, seq iter.Seq2[T0, T1]) func(i int) Tuple2[T0, T1] {
+	tb.Helper()
+	return pull(tb, func(yield func(Tuple2[T0, T1]) bool) {
+		for v0, v1 := range seq {
+			if !yield(Tuple2[T0, T1]{v0, v1}) {
+				return
+			}
+		}
+	})
+}
+
+// ########## 1 ##########
+
+// GeneratorProvider1 produces value tuples on demand, rather than storing them,
+// so that every call can get distinct values without allocating them all up
+// front.
+type GeneratorProvider1[T0 any] struct {
+	generator[Tuple1[T0]]
+	benchmark func()
+}
+
+// NewGenerator1 generates a new provider which calls `generate` with 0, 1, 2 and
+// so on for the values of every call to `target`.
+func NewGenerator1[T0 any](generate func(i int) T0, target func(T0)) *GeneratorProvider1[T0] {
+	return newGeneratorProvider1(func(i int) Tuple1[T0] {
+		v0 := generate(i)
+		return Tuple1[T0]{v0}
+	}, target)
+}
+
+// FromSeq1 generates a new provider which takes the values of every call to
+// `target` from the values of `seq` in order, and starts over when they run out.
+// An empty sequence fails `tb`, and the sequence is stopped when the test of
+// `tb` is done.
+func FromSeq1[T0 any](tb testing.TB, seq iter.Seq[T0], target func(T0)) *GeneratorProvider1[T0] {
+	tb.Helper()
+	return newGeneratorProvider1(pull(tb, func(yield func(Tuple1[T0]) bool) {
+		for v0 := range seq {
+			if !yield(Tuple1[T0]{v0}) {
+				return
+			}
+		}
+	}), target)
+}
+
+func newGeneratorProvider1[T0 any](generate func(i int) Tuple1[T0], target func(T0)) *GeneratorProvider1[T0] {
+	provider := new(GeneratorProvider1[T0])
+	provider.generate = generate
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// WithPrefetch sets the number of values that Prefetch generates ahead of time.
+// The timer of the sample is stopped every time the buffer is filled, so a
+// larger buffer stops it less often.
+func (this *GeneratorProvider1[T0]) WithPrefetch(size int) *GeneratorProvider1[T0] {
+	this.setPrefetch(size)
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *GeneratorProvider1[T0]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *GeneratorProvider1[T0]) WrapBenchmarkFunc(target func(T0)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *GeneratorProvider1[T0]) value() T0 {
+	tuple := this.next()
+	return tuple.Value0
+}
+
+// ########## 2 ##########
+
+// GeneratorProvider2 produces value tuples on demand, rather than storing them,
+// so that every call can get distinct values without allocating them all up
+// front.
+type GeneratorProvider2[T0 any, T1 any] struct {
+	generator[Tuple2[T0, T1]]
+	benchmark func()
+}
+
+// NewGenerator2 generates a new provider which calls `generate` with 0, 1, 2 and
+// so on for the values of every call to `target`.
+func NewGenerator2[T0 any, T1 any](generate func(i int) (T0, T1), target func(T0, T1)) *GeneratorProvider2[T0, T1] {
+	return newGeneratorProvider2(func(i int) Tuple2[T0, T1] {
+		v0, v1 := generate(i)
+		return Tuple2[T0, T1]{v0, v1}
+	}, target)
+}
+
+// FromSeq2 generates a new provider which takes the values of every call to
+// `target` from the pairs of `seq` in order, and starts over when they run out.
+// An empty sequence fails `tb`, and the sequence is stopped when the test of
+// `tb` is done.
+func FromSeq2[T0 any, T1 any](tb testing.TB, seq iter.Seq2[T0, T1], target func(T0, T1)) *GeneratorProvider2[T0, T1] {
+	tb.Helper()
+	return newGeneratorProvider2(pull2(tb, seq), target)
+}
+
+func newGeneratorProvider2[T0 any, T1 any](generate func(i int) Tuple2[T0, T1], target func(T0, T1)) *GeneratorProvider2[T0, T1] {
+	provider := new(GeneratorProvider2[T0, T1])
+	provider.generate = generate
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// WithPrefetch sets the number of values that Prefetch generates ahead of time.
+// The timer of the sample is stopped every time the buffer is filled, so a
+// larger buffer stops it less often.
+func (this *GeneratorProvider2[T0, T1]) WithPrefetch(size int) *GeneratorProvider2[T0, T1] {
+	this.setPrefetch(size)
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *GeneratorProvider2[T0, T1]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *GeneratorProvider2[T0, T1]) WrapBenchmarkFunc(target func(T0, T1)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *GeneratorProvider2[T0, T1]) value() (T0, T1) {
+	tuple := this.next()
+	return tuple.Value0, tuple.Value1
+}
+
+// ########## 3 ##########
+
+// GeneratorProvider3 produces value tuples on demand, rather than storing them,
+// so that every call can get distinct values without allocating them all up
+// front.
+type GeneratorProvider3[T0 any, T1 any, T2 any] struct {
+	generator[Tuple3[T0, T1, T2]]
+	benchmark func()
+}
+
+// NewGenerator3 generates a new provider which calls `generate` with 0, 1, 2 and
+// so on for the values of every call to `target`.
+func NewGenerator3[T0 any, T1 any, T2 any](generate func(i int) (T0, T1, T2), target func(T0, T1, T2)) *GeneratorProvider3[T0, T1, T2] {
+	return newGeneratorProvider3(func(i int) Tuple3[T0, T1, T2] {
+		v0, v1, v2 := generate(i)
+		return Tuple3[T0, T1, T2]{v0, v1, v2}
+	}, target)
+}
+
+// FromSeq3 generates a new provider which takes the values of every call to
+// `target` from the tuples of `seq` in order, and starts over when they run out.
+// An empty sequence fails `tb`, and the sequence is stopped when the test of
+// `tb` is done.
+func FromSeq3[T0 any, T1 any, T2 any](tb testing.TB, seq iter.Seq[Tuple3[T0, T1, T2]], target func(T0, T1, T2)) *GeneratorProvider3[T0, T1, T2] {
+	tb.Helper()
+	return newGeneratorProvider3(pull(tb, seq), target)
+}
+
+func newGeneratorProvider3[T0 any, T1 any, T2 any](generate func(i int) Tuple3[T0, T1, T2], target func(T0, T1, T2)) *GeneratorProvider3[T0, T1, T2] {
+	provider := new(GeneratorProvider3[T0, T1, T2])
+	provider.generate = generate
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// WithPrefetch sets the number of values that Prefetch generates ahead of time.
+// The timer of the sample is stopped every time the buffer is filled, so a
+// larger buffer stops it less often.
+func (this *GeneratorProvider3[T0, T1, T2]) WithPrefetch(size int) *GeneratorProvider3[T0, T1, T2] {
+	this.setPrefetch(size)
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *GeneratorProvider3[T0, T1, T2]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *GeneratorProvider3[T0, T1, T2]) WrapBenchmarkFunc(target func(T0, T1, T2)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *GeneratorProvider3[T0, T1, T2]) value() (T0, T1, T2) {
+	tuple := this.next()
+	return tuple.Value0, tuple.Value1, tuple.Value2
+}
+
+// ########## 4 ##########
+
+// GeneratorProvider4 produces value tuples on demand, rather than storing them,
+// so that every call can get distinct values without allocating them all up
+// front.
+type GeneratorProvider4[T0 any, T1 any, T2 any, T3 any] struct {
+	generator[Tuple4[T0, T1, T2, T3]]
+	benchmark func()
+}
+
+// NewGenerator4 generates a new provider which calls `generate` with 0, 1, 2 and
+// so on for the values of every call to `target`.
+func NewGenerator4[T0 any, T1 any, T2 any, T3 any](generate func(i int) (T0, T1, T2, T3), target func(T0, T1, T2, T3)) *GeneratorProvider4[T0, T1, T2, T3] {
+	return newGeneratorProvider4(func(i int) Tuple4[T0, T1, T2, T3] {
+		v0, v1, v2, v3 := generate(i)
+		return Tuple4[T0, T1, T2, T3]{v0, v1, v2, v3}
+	}, target)
+}
+
+// FromSeq4 generates a new provider which takes the values of every call to
+// `target` from the tuples of `seq` in order, and starts over when they run out.
+// An empty sequence fails `tb`, and the sequence is stopped when the test of
+// `tb` is done.
+func FromSeq4[T0 any, T1 any, T2 any, T3 any](tb testing.TB, seq iter.Seq[Tuple4[T0, T1, T2, T3]], target func(T0, T1, T2, T3)) *GeneratorProvider4[T0, T1, T2, T3] {
+	tb.Helper()
+	return newGeneratorProvider4(pull(tb, seq), target)
+}
+
+func newGeneratorProvider4[T0 any, T1 any, T2 any, T3 any](generate func(i int) Tuple4[T0, T1, T2, T3], target func(T0, T1, T2, T3)) *GeneratorProvider4[T0, T1, T2, T3] {
+	provider := new(GeneratorProvider4[T0, T1, T2, T3])
+	provider.generate = generate
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// WithPrefetch sets the number of values that Prefetch generates ahead of time.
+// The timer of the sample is stopped every time the buffer is filled, so a
+// larger buffer stops it less often.
+func (this *GeneratorProvider4[T0, T1, T2, T3]) WithPrefetch(size int) *GeneratorProvider4[T0, T1, T2, T3] {
+	this.setPrefetch(size)
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *GeneratorProvider4[T0, T1, T2, T3]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *GeneratorProvider4[T0, T1, T2, T3]) WrapBenchmarkFunc(target func(T0, T1, T2, T3)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *GeneratorProvider4[T0, T1, T2, T3]) value() (T0, T1, T2, T3) {
+	tuple := this.next()
+	return tuple.Value0, tuple.Value1, tuple.Value2, tuple.Value3
+}
+
+// ########## 5 ##########
+
+// GeneratorProvider5 produces value tuples on demand, rather than storing them,
+// so that every call can get distinct values without allocating them all up
+// front.
+type GeneratorProvider5[T0 any, T1 any, T2 any, T3 any, T4 any] struct {
+	generator[Tuple5[T0, T1, T2, T3, T4]]
+	benchmark func()
+}
+
+// NewGenerator5 generates a new provider which calls `generate` with 0, 1, 2 and
+// so on for the values of every call to `target`.
+func NewGenerator5[T0 any, T1 any, T2 any, T3 any, T4 any](generate func(i int) (T0, T1, T2, T3, T4), target func(T0, T1, T2, T3, T4)) *GeneratorProvider5[T0, T1, T2, T3, T4] {
+	return newGeneratorProvider5(func(i int) Tuple5[T0, T1, T2, T3, T4] {
+		v0, v1, v2, v3, v4 := generate(i)
+		return Tuple5[T0, T1, T2, T3, T4]{v0, v1, v2, v3, v4}
+	}, target)
+}
+
+// FromSeq5 generates a new provider which takes the values of every call to
+// `target` from the tuples of `seq` in order, and starts over when they run out.
+// An empty sequence fails `tb`, and the sequence is stopped when the test of
+// `tb` is done.
+func FromSeq5[T0 any, T1 any, T2 any, T3 any, T4 any](tb testing.TB, seq iter.Seq[Tuple5[T0, T1, T2, T3, T4]], target func(T0, T1, T2, T3, T4)) *GeneratorProvider5[T0, T1, T2, T3, T4] {
+	tb.Helper()
+	return newGeneratorProvider5(pull(tb, seq), target)
+}
+
+func newGeneratorProvider5[T0 any, T1 any, T2 any, T3 any, T4 any](generate func(i int) Tuple5[T0, T1, T2, T3, T4], target func(T0, T1, T2, T3, T4)) *GeneratorProvider5[T0, T1, T2, T3, T4] {
+	provider := new(GeneratorProvider5[T0, T1, T2, T3, T4])
+	provider.generate = generate
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// WithPrefetch sets the number of values that Prefetch generates ahead of time.
+// The timer of the sample is stopped every time the buffer is filled, so a
+// larger buffer stops it less often.
+func (this *GeneratorProvider5[T0, T1, T2, T3, T4]) WithPrefetch(size int) *GeneratorProvider5[T0, T1, T2, T3, T4] {
+	this.setPrefetch(size)
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *GeneratorProvider5[T0, T1, T2, T3, T4]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *GeneratorProvider5[T0, T1, T2, T3, T4]) WrapBenchmarkFunc(target func(T0, T1, T2, T3, T4)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *GeneratorProvider5[T0, T1, T2, T3, T4]) value() (T0, T1, T2, T3, T4) {
+	tuple := this.next()
+	return tuple.Value0, tuple.Value1, tuple.Value2, tuple.Value3, tuple.Value4
+}
+
+// ########## 6 ##########
+
+// GeneratorProvider6 produces value tuples on demand, rather than storing them,
+// so that every call can get distinct values without allocating them all up
+// front.
+type GeneratorProvider6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any] struct {
+	generator[Tuple6[T0, T1, T2, T3, T4, T5]]
+	benchmark func()
+}
+
+// NewGenerator6 generates a new provider which calls `generate` with 0, 1, 2 and
+// so on for the values of every call to `target`.
+func NewGenerator6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any](generate func(i int) (T0, T1, T2, T3, T4, T5), target func(T0, T1, T2, T3, T4, T5)) *GeneratorProvider6[T0, T1, T2, T3, T4, T5] {
+	return newGeneratorProvider6(func(i int) Tuple6[T0, T1, T2, T3, T4, T5] {
+		v0, v1, v2, v3, v4, v5 := generate(i)
+		return Tuple6[T0, T1, T2, T3, T4, T5]{v0, v1, v2, v3, v4, v5}
+	}, target)
+}
+
+// FromSeq6 generates a new provider which takes the values of every call to
+// `target` from the tuples of `seq` in order, and starts over when they run out.
+// An empty sequence fails `tb`, and the sequence is stopped when the test of
+// `tb` is done.
+func FromSeq6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any](tb testing.TB, seq iter.Seq[Tuple6[T0, T1, T2, T3, T4, T5]], target func(T0, T1, T2, T3, T4, T5)) *GeneratorProvider6[T0, T1, T2, T3, T4, T5] {
+	tb.Helper()
+	return newGeneratorProvider6(pull(tb, seq), target)
+}
+
+func newGeneratorProvider6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any](generate func(i int) Tuple6[T0, T1, T2, T3, T4, T5], target func(T0, T1, T2, T3, T4, T5)) *GeneratorProvider6[T0, T1, T2, T3, T4, T5] {
+	provider := new(GeneratorProvider6[T0, T1, T2, T3, T4, T5])
+	provider.generate = generate
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// WithPrefetch sets the number of values that Prefetch generates ahead of time.
+// The timer of the sample is stopped every time the buffer is filled, so a
+// larger buffer stops it less often.
+func (this *GeneratorProvider6[T0, T1, T2, T3, T4, T5]) WithPrefetch(size int) *GeneratorProvider6[T0, T1, T2, T3, T4, T5] {
+	this.setPrefetch(size)
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *GeneratorProvider6[T0, T1, T2, T3, T4, T5]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *GeneratorProvider6[T0, T1, T2, T3, T4, T5]) WrapBenchmarkFunc(target func(T0, T1, T2, T3, T4, T5)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *GeneratorProvider6[T0, T1, T2, T3, T4, T5]) value() (T0, T1, T2, T3, T4, T5) {
+	tuple := this.next()
+	return tuple.Value0, tuple.Value1, tuple.Value2, tuple.Value3, tuple.Value4, tuple.Value5
+}
@@ -0,0 +1,127 @@
+package providers
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestNewGenerator_CallsWithIncreasingIndex(t *testing.T) {
+	actual := make([]Tuple2[int, string], 0)
+	provider := NewGenerator2(
+		func(i int) (int, string) { return i, strconv.Itoa(i * 10) },
+		func(number int, text string) { actual = append(actual, Tuple2[int, string]{number, text}) })
+
+	benchmark := provider.BenchmarkFunc()
+	for range 3 {
+		benchmark()
+	}
+
+	expected := []Tuple2[int, string]{{0, "0"}, {1, "10"}, {2, "20"}}
+	if !reflect.DeepEqual(expected, actual) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestGenerator_PrefetchGeneratesWhileUntimed(t *testing.T) {
+	paused := false
+	pauses := 0
+	untimed := func(function func()) {
+		paused = true
+		pauses++
+		function()
+		paused = false
+	}
+
+	actual := make([]int, 0)
+	provider := NewGenerator1(
+		func(i int) int {
+			if !paused {
+				t.Errorf("expected value %d to be generated while untimed", i)
+			}
+
+			return i
+		},
+		func(value int) { actual = append(actual, value) }).
+		WithPrefetch(3)
+	benchmark := provider.BenchmarkFunc()
+
+	provider.Prefetch(untimed)
+	benchmark()
+	benchmark()
+	provider.Prefetch(untimed)
+	for range 7 {
+		benchmark()
+	}
+
+	if !slices.Equal([]int{0, 1, 2, 3, 4, 5, 6, 7, 8}, actual) {
+		t.Errorf("expected the values in order, without the unused ones being lost, got %v", actual)
+	}
+
+	// two prefetches and the two times the buffer ran out
+	if pauses != 4 {
+		t.Errorf("expected the buffer to be filled 4 times, got %d", pauses)
+	}
+}
+
+func TestFromSeq_StartsOverWhenExhausted(t *testing.T) {
+	actual := make([]string, 0)
+	provider := FromSeq1(t, slices.Values([]string{"a", "b"}), func(value string) { actual = append(actual, value) })
+
+	benchmark := provider.BenchmarkFunc()
+	for range 5 {
+		benchmark()
+	}
+
+	if !slices.Equal([]string{"a", "b", "a", "b", "a"}, actual) {
+		t.Errorf("expected the sequence to repeat, got %v", actual)
+	}
+}
+
+func TestFromSeq2(t *testing.T) {
+	actual := make(map[string]int)
+	provider := FromSeq2(t, maps.All(map[string]int{"one": 1, "two": 2}), func(key string, value int) { actual[key] = value })
+
+	benchmark := provider.BenchmarkFunc()
+	benchmark()
+	benchmark()
+
+	if !reflect.DeepEqual(map[string]int{"one": 1, "two": 2}, actual) {
+		t.Errorf("expected both pairs, got %v", actual)
+	}
+}
+
+func TestFromSeq_EmptySequence(t *testing.T) {
+	recorder := &fatalRecorder{TB: t}
+
+	FromSeq1(recorder, slices.Values([]string{}), func(string) {})
+
+	if !strings.Contains(recorder.message, "has no values") {
+		t.Errorf("expected a failure for an empty sequence, got %q", recorder.message)
+	}
+}
+
+func TestFromSeq_StopsSequenceWhenTestIsDone(t *testing.T) {
+	stopped := false
+	seq := func(yield func(int) bool) {
+		defer func() { stopped = true }()
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	t.Run("benchmark", func(t *testing.T) {
+		benchmark := FromSeq1(t, seq, func(int) {}).BenchmarkFunc()
+		benchmark()
+		benchmark()
+	})
+
+	if !stopped {
+		t.Error("expected the sequence to be stopped when the test is done")
+	}
+}
@@ -8,6 +8,15 @@ type FuncProvider interface {
 	BenchmarkFunc() func()
 }
 
+// Prefetcher generates the values of a provider ahead of time, so that
+// generating them is not measured.
+type Prefetcher interface {
+	// Prefetch generates values before a sample. `untimed` runs a function
+	// while the sample is not measured, and can be used again while the sample
+	// runs.
+	Prefetch(untimed func(function func()))
+}
+
 // Provider1 is a benchmark data-injector and provider.
 type Provider1[T0 any] interface {
 	FuncProvider
@@ -0,0 +1,261 @@
+package providers
+
+import (
+	"math/rand/v2"
+)
+
+// randomSelector draws every row uniformly, so that the order of the rows
+// cannot be learned by branch predictors and caches.
+type randomSelector struct {
+	random *rand.Rand
+}
+
+func (this *randomSelector) next(count int) int {
+	return this.random.IntN(count)
+}
+
+// shuffledSelector visits every row once per pass, in an order that is
+// shuffled again for every pass.
+type shuffledSelector struct {
+	random   *rand.Rand
+	order    []int
+	position int
+}
+
+func (this *shuffledSelector) next(count int) int {
+	if len(this.order) != count {
+		this.order = make([]int, count)
+		for index := range this.order {
+			this.order[index] = index
+		}
+
+		this.position = count
+	}
+
+	if this.position >= count {
+		this.random.Shuffle(count, func(i int, j int) {
+			this.order[i], this.order[j] = this.order[j], this.order[i]
+		})
+		this.position = 0
+	}
+
+	index := this.order[this.position]
+	this.position++
+	return index
+}
+
+// ########## 1 ##########
+
+// RandomProvider1 stores value tuples which are drawn uniformly at random.
+type RandomProvider1[T0 any] struct {
+	selectingProvider1[T0]
+	seeded
+}
+
+// NewRandom1 generates a new provider which draws its rows uniformly at random
+// with the `seed`. A seed of 0 picks a random seed, which is printed.
+func NewRandom1[T0 any](seed uint64, target func(T0)) *RandomProvider1[T0] {
+	provider := new(RandomProvider1[T0])
+	provider.seed = selectSeed(seed)
+	provider.selector = &randomSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ShuffledRingProvider1 stores value tuples which are accessed once per pass, in
+// a newly shuffled order for every pass.
+type ShuffledRingProvider1[T0 any] struct {
+	selectingProvider1[T0]
+	seeded
+}
+
+// NewShuffledRing1 generates a new provider which accesses every row once per
+// pass, shuffled with the `seed`. A seed of 0 picks a random seed, which is
+// printed.
+func NewShuffledRing1[T0 any](seed uint64, target func(T0)) *ShuffledRingProvider1[T0] {
+	provider := new(ShuffledRingProvider1[T0])
+	provider.seed = selectSeed(seed)
+	provider.selector = &shuffledSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ########## 2 ##########
+
+// RandomProvider2 stores value tuples which are drawn uniformly at random.
+type RandomProvider2[T0 any, T1 any] struct {
+	selectingProvider2[T0, T1]
+	seeded
+}
+
+// NewRandom2 generates a new provider which draws its rows uniformly at random
+// with the `seed`. A seed of 0 picks a random seed, which is printed.
+func NewRandom2[T0 any, T1 any](seed uint64, target func(T0, T1)) *RandomProvider2[T0, T1] {
+	provider := new(RandomProvider2[T0, T1])
+	provider.seed = selectSeed(seed)
+	provider.selector = &randomSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ShuffledRingProvider2 stores value tuples which are accessed once per pass, in
+// a newly shuffled order for every pass.
+type ShuffledRingProvider2[T0 any, T1 any] struct {
+	selectingProvider2[T0, T1]
+	seeded
+}
+
+// NewShuffledRing2 generates a new provider which accesses every row once per
+// pass, shuffled with the `seed`. A seed of 0 picks a random seed, which is
+// printed.
+func NewShuffledRing2[T0 any, T1 any](seed uint64, target func(T0, T1)) *ShuffledRingProvider2[T0, T1] {
+	provider := new(ShuffledRingProvider2[T0, T1])
+	provider.seed = selectSeed(seed)
+	provider.selector = &shuffledSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ########## 3 ##########
+
+// RandomProvider3 stores value tuples which are drawn uniformly at random.
+type RandomProvider3[T0 any, T1 any, T2 any] struct {
+	selectingProvider3[T0, T1, T2]
+	seeded
+}
+
+// NewRandom3 generates a new provider which draws its rows uniformly at random
+// with the `seed`. A seed of 0 picks a random seed, which is printed.
+func NewRandom3[T0 any, T1 any, T2 any](seed uint64, target func(T0, T1, T2)) *RandomProvider3[T0, T1, T2] {
+	provider := new(RandomProvider3[T0, T1, T2])
+	provider.seed = selectSeed(seed)
+	provider.selector = &randomSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ShuffledRingProvider3 stores value tuples which are accessed once per pass, in
+// a newly shuffled order for every pass.
+type ShuffledRingProvider3[T0 any, T1 any, T2 any] struct {
+	selectingProvider3[T0, T1, T2]
+	seeded
+}
+
+// NewShuffledRing3 generates a new provider which accesses every row once per
+// pass, shuffled with the `seed`. A seed of 0 picks a random seed, which is
+// printed.
+func NewShuffledRing3[T0 any, T1 any, T2 any](seed uint64, target func(T0, T1, T2)) *ShuffledRingProvider3[T0, T1, T2] {
+	provider := new(ShuffledRingProvider3[T0, T1, T2])
+	provider.seed = selectSeed(seed)
+	provider.selector = &shuffledSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ########## 4 ##########
+
+// RandomProvider4 stores value tuples which are drawn uniformly at random.
+type RandomProvider4[T0 any, T1 any, T2 any, T3 any] struct {
+	selectingProvider4[T0, T1, T2, T3]
+	seeded
+}
+
+// NewRandom4 generates a new provider which draws its rows uniformly at random
+// with the `seed`. A seed of 0 picks a random seed, which is printed.
+func NewRandom4[T0 any, T1 any, T2 any, T3 any](seed uint64, target func(T0, T1, T2, T3)) *RandomProvider4[T0, T1, T2, T3] {
+	provider := new(RandomProvider4[T0, T1, T2, T3])
+	provider.seed = selectSeed(seed)
+	provider.selector = &randomSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ShuffledRingProvider4 stores value tuples which are accessed once per pass, in
+// a newly shuffled order for every pass.
+type ShuffledRingProvider4[T0 any, T1 any, T2 any, T3 any] struct {
+	selectingProvider4[T0, T1, T2, T3]
+	seeded
+}
+
+// NewShuffledRing4 generates a new provider which accesses every row once per
+// pass, shuffled with the `seed`. A seed of 0 picks a random seed, which is
+// printed.
+func NewShuffledRing4[T0 any, T1 any, T2 any, T3 any](seed uint64, target func(T0, T1, T2, T3)) *ShuffledRingProvider4[T0, T1, T2, T3] {
+	provider := new(ShuffledRingProvider4[T0, T1, T2, T3])
+	provider.seed = selectSeed(seed)
+	provider.selector = &shuffledSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ########## 5 ##########
+
+// RandomProvider5 stores value tuples which are drawn uniformly at random.
+type RandomProvider5[T0 any, T1 any, T2 any, T3 any, T4 any] struct {
+	selectingProvider5[T0, T1, T2, T3, T4]
+	seeded
+}
+
+// NewRandom5 generates a new provider which draws its rows uniformly at random
+// with the `seed`. A seed of 0 picks a random seed, which is printed.
+func NewRandom5[T0 any, T1 any, T2 any, T3 any, T4 any](seed uint64, target func(T0, T1, T2, T3, T4)) *RandomProvider5[T0, T1, T2, T3, T4] {
+	provider := new(RandomProvider5[T0, T1, T2, T3, T4])
+	provider.seed = selectSeed(seed)
+	provider.selector = &randomSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ShuffledRingProvider5 stores value tuples which are accessed once per pass, in
+// a newly shuffled order for every pass.
+type ShuffledRingProvider5[T0 any, T1 any, T2 any, T3 any, T4 any] struct {
+	selectingProvider5[T0, T1, T2, T3, T4]
+	seeded
+}
+
+// NewShuffledRing5 generates a new provider which accesses every row once per
+// pass, shuffled with the `seed`. A seed of 0 picks a random seed, which is
+// printed.
+func NewShuffledRing5[T0 any, T1 any, T2 any, T3 any, T4 any](seed uint64, target func(T0, T1, T2, T3, T4)) *ShuffledRingProvider5[T0, T1, T2, T3, T4] {
+	provider := new(ShuffledRingProvider5[T0, T1, T2, T3, T4])
+	provider.seed = selectSeed(seed)
+	provider.selector = &shuffledSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ########## 6 ##########
+
+// RandomProvider6 stores value tuples which are drawn uniformly at random.
+type RandomProvider6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any] struct {
+	selectingProvider6[T0, T1, T2, T3, T4, T5]
+	seeded
+}
+
+// NewRandom6 generates a new provider which draws its rows uniformly at random
+// with the `seed`. A seed of 0 picks a random seed, which is printed.
+func NewRandom6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any](seed uint64, target func(T0, T1, T2, T3, T4, T5)) *RandomProvider6[T0, T1, T2, T3, T4, T5] {
+	provider := new(RandomProvider6[T0, T1, T2, T3, T4, T5])
+	provider.seed = selectSeed(seed)
+	provider.selector = &randomSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// ShuffledRingProvider6 stores value tuples which are accessed once per pass, in
+// a newly shuffled order for every pass.
+type ShuffledRingProvider6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any] struct {
+	selectingProvider6[T0, T1, T2, T3, T4, T5]
+	seeded
+}
+
+// NewShuffledRing6 generates a new provider which accesses every row once per
+// pass, shuffled with the `seed`. A seed of 0 picks a random seed, which is
+// printed.
+func NewShuffledRing6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any](seed uint64, target func(T0, T1, T2, T3, T4, T5)) *ShuffledRingProvider6[T0, T1, T2, T3, T4, T5] {
+	provider := new(ShuffledRingProvider6[T0, T1, T2, T3, T4, T5])
+	provider.seed = selectSeed(seed)
+	provider.selector = &shuffledSelector{random: newRandom(provider.seed)}
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
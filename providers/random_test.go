@@ -0,0 +1,51 @@
+package providers
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestShuffledSelector_VisitsEveryRowOncePerPass(t *testing.T) {
+	selector := &shuffledSelector{random: newRandom(42)}
+
+	for pass := 0; pass < 3; pass++ {
+		visited := make([]int, 0, 10)
+		for range 10 {
+			visited = append(visited, selector.next(10))
+		}
+
+		slices.Sort(visited)
+		if !slices.Equal(visited, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+			t.Errorf("expected every row once in pass %d, got %v", pass, visited)
+		}
+	}
+}
+
+func TestRandomProvider_IsReproducibleFromSeed(t *testing.T) {
+	draw := func(seed uint64) []int {
+		drawn := make([]int, 0, 20)
+		provider := NewRandom1(seed, func(value int) { drawn = append(drawn, value) })
+		for value := range 5 {
+			provider.Add(value)
+		}
+
+		benchmark := provider.BenchmarkFunc()
+		for range 20 {
+			benchmark()
+		}
+
+		return drawn
+	}
+
+	first, second := draw(7), draw(7)
+
+	if !slices.Equal(first, second) {
+		t.Errorf("expected the same rows for the same seed, got %v and %v", first, second)
+	}
+}
+
+func TestProvidersImplementInterfaces(t *testing.T) {
+	var _ Provider1[int] = NewRandom1(1, func(int) {})
+	var _ Provider2[int, string] = NewShuffledRing2(1, func(int, string) {})
+	var _ Provider6[int, int, int, int, int, int] = NewRandom6(1, func(int, int, int, int, int, int) {})
+}
@@ -0,0 +1,47 @@
+package providers
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"sync"
+
+	"github.com/smarty/benchy/internal/params"
+)
+
+var (
+	defaultSeed     uint64
+	defaultSeedOnce sync.Once
+)
+
+// selectSeed looks for the seed in the CLI flags first, then at `seed`.
+// Without either, a random seed is picked once for all providers and printed,
+// so that the run can be reproduced.
+func selectSeed(seed uint64) uint64 {
+	if seed = params.SelectSeed(seed, os.Args); seed != 0 {
+		return seed
+	}
+
+	defaultSeedOnce.Do(func() {
+		defaultSeed = rand.Uint64()
+		_, _ = fmt.Fprintf(os.Stderr, "benchy: random providers use seed %d, reproduce with -test.benchy.seed=%d\n", defaultSeed, defaultSeed)
+	})
+
+	return defaultSeed
+}
+
+// seeded records the seed that a provider draws its rows with.
+type seeded struct {
+	seed uint64
+}
+
+// Seed returns the seed that rows are drawn with. Passing it to the
+// constructor, or as `-test.benchy.seed` in the CLI flags, draws the same
+// rows in the same order.
+func (this seeded) Seed() uint64 {
+	return this.seed
+}
+
+func newRandom(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
@@ -0,0 +1,222 @@
+package providers
+
+// selector picks the index of the row that a provider hands to the benchmark
+// function next.
+type selector interface {
+	// next selects one of `count` rows, which is at least 1.
+	next(count int) int
+}
+
+// selectingProvider1 through selectingProvider6 store value tuples, which are
+// accessed in the order picked by their selector. They implement the ProviderN
+// interfaces for the providers that embed them.
+
+// ########## 1 ##########
+
+type selectingProvider1[T0 any] struct {
+	selector  selector
+	values    []Tuple1[T0]
+	benchmark func()
+}
+
+// Add adds a new row to this provider.
+func (this *selectingProvider1[T0]) Add(v0 T0) Provider1[T0] {
+	this.values = append(this.values, Tuple1[T0]{v0})
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *selectingProvider1[T0]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *selectingProvider1[T0]) WrapBenchmarkFunc(target func(T0)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *selectingProvider1[T0]) value() T0 {
+	tuple := this.values[this.selector.next(len(this.values))]
+	return tuple.Value0
+}
+
+// ########## 2 ##########
+
+type selectingProvider2[T0 any, T1 any] struct {
+	selector  selector
+	values    []Tuple2[T0, T1]
+	benchmark func()
+}
+
+// Add adds a new row to this provider.
+func (this *selectingProvider2[T0, T1]) Add(v0 T0, v1 T1) Provider2[T0, T1] {
+	this.values = append(this.values, Tuple2[T0, T1]{v0, v1})
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *selectingProvider2[T0, T1]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *selectingProvider2[T0, T1]) WrapBenchmarkFunc(target func(T0, T1)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *selectingProvider2[T0, T1]) value() (T0, T1) {
+	tuple := this.values[this.selector.next(len(this.values))]
+	return tuple.Value0, tuple.Value1
+}
+
+// ########## 3 ##########
+
+type selectingProvider3[T0 any, T1 any, T2 any] struct {
+	selector  selector
+	values    []Tuple3[T0, T1, T2]
+	benchmark func()
+}
+
+// Add adds a new row to this provider.
+func (this *selectingProvider3[T0, T1, T2]) Add(v0 T0, v1 T1, v2 T2) Provider3[T0, T1, T2] {
+	this.values = append(this.values, Tuple3[T0, T1, T2]{v0, v1, v2})
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *selectingProvider3[T0, T1, T2]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *selectingProvider3[T0, T1, T2]) WrapBenchmarkFunc(target func(T0, T1, T2)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *selectingProvider3[T0, T1, T2]) value() (T0, T1, T2) {
+	tuple := this.values[this.selector.next(len(this.values))]
+	return tuple.Value0, tuple.Value1, tuple.Value2
+}
+
+// ########## 4 ##########
+
+type selectingProvider4[T0 any, T1 any, T2 any, T3 any] struct {
+	selector  selector
+	values    []Tuple4[T0, T1, T2, T3]
+	benchmark func()
+}
+
+// Add adds a new row to this provider.
+func (this *selectingProvider4[T0, T1, T2, T3]) Add(v0 T0, v1 T1, v2 T2, v3 T3) Provider4[T0, T1, T2, T3] {
+	this.values = append(this.values, Tuple4[T0, T1, T2, T3]{v0, v1, v2, v3})
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *selectingProvider4[T0, T1, T2, T3]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *selectingProvider4[T0, T1, T2, T3]) WrapBenchmarkFunc(target func(T0, T1, T2, T3)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *selectingProvider4[T0, T1, T2, T3]) value() (T0, T1, T2, T3) {
+	tuple := this.values[this.selector.next(len(this.values))]
+	return tuple.Value0, tuple.Value1, tuple.Value2, tuple.Value3
+}
+
+// ########## 5 ##########
+
+type selectingProvider5[T0 any, T1 any, T2 any, T3 any, T4 any] struct {
+	selector  selector
+	values    []Tuple5[T0, T1, T2, T3, T4]
+	benchmark func()
+}
+
+// Add adds a new row to this provider.
+func (this *selectingProvider5[T0, T1, T2, T3, T4]) Add(v0 T0, v1 T1, v2 T2, v3 T3, v4 T4) Provider5[T0, T1, T2, T3, T4] {
+	this.values = append(this.values, Tuple5[T0, T1, T2, T3, T4]{v0, v1, v2, v3, v4})
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *selectingProvider5[T0, T1, T2, T3, T4]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *selectingProvider5[T0, T1, T2, T3, T4]) WrapBenchmarkFunc(target func(T0, T1, T2, T3, T4)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *selectingProvider5[T0, T1, T2, T3, T4]) value() (T0, T1, T2, T3, T4) {
+	tuple := this.values[this.selector.next(len(this.values))]
+	return tuple.Value0, tuple.Value1, tuple.Value2, tuple.Value3, tuple.Value4
+}
+
+// ########## 6 ##########
+
+type selectingProvider6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any] struct {
+	selector  selector
+	values    []Tuple6[T0, T1, T2, T3, T4, T5]
+	benchmark func()
+}
+
+// Add adds a new row to this provider.
+func (this *selectingProvider6[T0, T1, T2, T3, T4, T5]) Add(v0 T0, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5) Provider6[T0, T1, T2, T3, T4, T5] {
+	this.values = append(this.values, Tuple6[T0, T1, T2, T3, T4, T5]{v0, v1, v2, v3, v4, v5})
+	return this
+}
+
+// BenchmarkFunc returns a niladic function which wraps the template
+// function that was passed during construction. The returned function
+// can be used as a benchmark function with Benchy.
+func (this *selectingProvider6[T0, T1, T2, T3, T4, T5]) BenchmarkFunc() func() {
+	return this.benchmark
+}
+
+// WrapBenchmarkFunc takes a function which matches the required signature
+// and returns a niladic function that can be passed into Benchy as a
+// benchmark function.
+func (this *selectingProvider6[T0, T1, T2, T3, T4, T5]) WrapBenchmarkFunc(target func(T0, T1, T2, T3, T4, T5)) func() {
+	return func() {
+		target(this.value())
+	}
+}
+
+func (this *selectingProvider6[T0, T1, T2, T3, T4, T5]) value() (T0, T1, T2, T3, T4, T5) {
+	tuple := this.values[this.selector.next(len(this.values))]
+	return tuple.Value0, tuple.Value1, tuple.Value2, tuple.Value3, tuple.Value4, tuple.Value5
+}
@@ -1,5 +1,11 @@
 package providers
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
 // Tuple1 is a carrier for 1 value. This exists only for the sake of consistency.
 type Tuple1[T0 any] struct {
 	Value0 T0
@@ -44,3 +50,95 @@ type Tuple6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any] struct {
 	Value4 T4
 	Value5 T5
 }
+
+// UnmarshalJSON decodes the tuple from an array of 1 value, such as a line of a
+// JSONL file, or from an object with the fields of the tuple.
+func (this *Tuple1[T0]) UnmarshalJSON(data []byte) error {
+	type tuple Tuple1[T0]
+	if !isJSONArray(data) {
+		return json.Unmarshal(data, (*tuple)(this))
+	}
+
+	return unmarshalJSONArray(data, &this.Value0)
+}
+
+// UnmarshalJSON decodes the tuple from an array of 2 values, such as a line of a
+// JSONL file, or from an object with the fields of the tuple.
+func (this *Tuple2[T0, T1]) UnmarshalJSON(data []byte) error {
+	type tuple Tuple2[T0, T1]
+	if !isJSONArray(data) {
+		return json.Unmarshal(data, (*tuple)(this))
+	}
+
+	return unmarshalJSONArray(data, &this.Value0, &this.Value1)
+}
+
+// UnmarshalJSON decodes the tuple from an array of 3 values, such as a line of a
+// JSONL file, or from an object with the fields of the tuple.
+func (this *Tuple3[T0, T1, T2]) UnmarshalJSON(data []byte) error {
+	type tuple Tuple3[T0, T1, T2]
+	if !isJSONArray(data) {
+		return json.Unmarshal(data, (*tuple)(this))
+	}
+
+	return unmarshalJSONArray(data, &this.Value0, &this.Value1, &this.Value2)
+}
+
+// UnmarshalJSON decodes the tuple from an array of 4 values, such as a line of a
+// JSONL file, or from an object with the fields of the tuple.
+func (this *Tuple4[T0, T1, T2, T3]) UnmarshalJSON(data []byte) error {
+	type tuple Tuple4[T0, T1, T2, T3]
+	if !isJSONArray(data) {
+		return json.Unmarshal(data, (*tuple)(this))
+	}
+
+	return unmarshalJSONArray(data, &this.Value0, &this.Value1, &this.Value2, &this.Value3)
+}
+
+// UnmarshalJSON decodes the tuple from an array of 5 values, such as a line of a
+// JSONL file, or from an object with the fields of the tuple.
+func (this *Tuple5[T0, T1, T2, T3, T4]) UnmarshalJSON(data []byte) error {
+	type tuple Tuple5[T0, T1, T2, T3, T4]
+	if !isJSONArray(data) {
+		return json.Unmarshal(data, (*tuple)(this))
+	}
+
+	return unmarshalJSONArray(data, &this.Value0, &this.Value1, &this.Value2, &this.Value3, &this.Value4)
+}
+
+// UnmarshalJSON decodes the tuple from an array of 6 values, such as a line of a
+// JSONL file, or from an object with the fields of the tuple.
+func (this *Tuple6[T0, T1, T2, T3, T4, T5]) UnmarshalJSON(data []byte) error {
+	type tuple Tuple6[T0, T1, T2, T3, T4, T5]
+	if !isJSONArray(data) {
+		return json.Unmarshal(data, (*tuple)(this))
+	}
+
+	return unmarshalJSONArray(data, &this.Value0, &this.Value1, &this.Value2, &this.Value3, &this.Value4, &this.Value5)
+}
+
+func isJSONArray(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("["))
+}
+
+// unmarshalJSONArray decodes every element of the array into the value at the
+// same position, and requires the array to have as many elements as there are
+// values.
+func unmarshalJSONArray(data []byte, values ...any) error {
+	elements := make([]json.RawMessage, 0, len(values))
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	if len(elements) != len(values) {
+		return fmt.Errorf("expected an array of %d values, got %d", len(values), len(elements))
+	}
+
+	for iElement, element := range elements {
+		if err := json.Unmarshal(element, values[iElement]); err != nil {
+			return fmt.Errorf("value %d: %w", iElement, err)
+		}
+	}
+
+	return nil
+}
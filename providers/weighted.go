@@ -0,0 +1,292 @@
+package providers
+
+import (
+	"math/rand/v2"
+)
+
+// weightedSelector draws rows in proportion to their weights, in constant time
+// per row with the alias method. The tables are built again when rows were
+// added since the last draw.
+type weightedSelector struct {
+	random      *rand.Rand
+	weights     []float64
+	probability []float64
+	alias       []int
+}
+
+func (this *weightedSelector) add(weight float64) {
+	this.weights = append(this.weights, max(0, weight))
+}
+
+func (this *weightedSelector) next(count int) int {
+	if len(this.probability) != count {
+		this.build(count)
+	}
+
+	index := this.random.IntN(count)
+	if this.random.Float64() < this.probability[index] {
+		return index
+	}
+
+	return this.alias[index]
+}
+
+// build creates the tables of the alias method (Vose). Every row gets an equal
+// share, of which the part that exceeds its weight goes to a heavier row.
+func (this *weightedSelector) build(count int) {
+	weights := make([]float64, count)
+	total := 0.0
+	for index := range weights {
+		weights[index] = 1
+		if index < len(this.weights) {
+			weights[index] = this.weights[index]
+		}
+
+		total += weights[index]
+	}
+
+	this.probability = make([]float64, count)
+	this.alias = make([]int, count)
+	small, large := make([]int, 0, count), make([]int, 0, count)
+	for index, weight := range weights {
+		// rows are drawn uniformly when no row has any weight
+		weights[index] = 1
+		if total > 0 {
+			weights[index] = weight * float64(count) / total
+		}
+
+		if weights[index] < 1 {
+			small = append(small, index)
+		} else {
+			large = append(large, index)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		lighter, heavier := small[len(small)-1], large[len(large)-1]
+		small, large = small[:len(small)-1], large[:len(large)-1]
+		this.probability[lighter] = weights[lighter]
+		this.alias[lighter] = heavier
+		weights[heavier] -= 1 - weights[lighter]
+		if weights[heavier] < 1 {
+			small = append(small, heavier)
+		} else {
+			large = append(large, heavier)
+		}
+	}
+
+	// what is left has a share of 1, apart from rounding errors
+	for _, index := range append(small, large...) {
+		this.probability[index] = 1
+	}
+}
+
+// ########## 1 ##########
+
+// WeightedProvider1 stores value tuples which are drawn at random, in proportion
+// to the weight of each row.
+type WeightedProvider1[T0 any] struct {
+	selectingProvider1[T0]
+	seeded
+	weights *weightedSelector
+}
+
+// NewWeighted1 generates a new provider which draws its rows at random with the
+// `seed`, in proportion to their weights. A seed of 0 picks a random seed,
+// which is printed.
+func NewWeighted1[T0 any](seed uint64, target func(T0)) *WeightedProvider1[T0] {
+	provider := new(WeightedProvider1[T0])
+	provider.seed = selectSeed(seed)
+	provider.weights = &weightedSelector{random: newRandom(provider.seed)}
+	provider.selector = provider.weights
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// Add adds a new row with a weight of 1 to this provider.
+func (this *WeightedProvider1[T0]) Add(v0 T0) Provider1[T0] {
+	return this.AddWeighted(1, v0)
+}
+
+// AddWeighted adds a new row to this provider, which is drawn in proportion to
+// its `weight`. Rows with a weight of 0 or less are never drawn.
+func (this *WeightedProvider1[T0]) AddWeighted(weight float64, v0 T0) *WeightedProvider1[T0] {
+	this.weights.add(weight)
+	this.selectingProvider1.Add(v0)
+	return this
+}
+
+// ########## 2 ##########
+
+// WeightedProvider2 stores value tuples which are drawn at random, in proportion
+// to the weight of each row.
+type WeightedProvider2[T0 any, T1 any] struct {
+	selectingProvider2[T0, T1]
+	seeded
+	weights *weightedSelector
+}
+
+// NewWeighted2 generates a new provider which draws its rows at random with the
+// `seed`, in proportion to their weights. A seed of 0 picks a random seed,
+// which is printed.
+func NewWeighted2[T0 any, T1 any](seed uint64, target func(T0, T1)) *WeightedProvider2[T0, T1] {
+	provider := new(WeightedProvider2[T0, T1])
+	provider.seed = selectSeed(seed)
+	provider.weights = &weightedSelector{random: newRandom(provider.seed)}
+	provider.selector = provider.weights
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// Add adds a new row with a weight of 1 to this provider.
+func (this *WeightedProvider2[T0, T1]) Add(v0 T0, v1 T1) Provider2[T0, T1] {
+	return this.AddWeighted(1, v0, v1)
+}
+
+// AddWeighted adds a new row to this provider, which is drawn in proportion to
+// its `weight`. Rows with a weight of 0 or less are never drawn.
+func (this *WeightedProvider2[T0, T1]) AddWeighted(weight float64, v0 T0, v1 T1) *WeightedProvider2[T0, T1] {
+	this.weights.add(weight)
+	this.selectingProvider2.Add(v0, v1)
+	return this
+}
+
+// ########## 3 ##########
+
+// WeightedProvider3 stores value tuples which are drawn at random, in proportion
+// to the weight of each row.
+type WeightedProvider3[T0 any, T1 any, T2 any] struct {
+	selectingProvider3[T0, T1, T2]
+	seeded
+	weights *weightedSelector
+}
+
+// NewWeighted3 generates a new provider which draws its rows at random with the
+// `seed`, in proportion to their weights. A seed of 0 picks a random seed,
+// which is printed.
+func NewWeighted3[T0 any, T1 any, T2 any](seed uint64, target func(T0, T1, T2)) *WeightedProvider3[T0, T1, T2] {
+	provider := new(WeightedProvider3[T0, T1, T2])
+	provider.seed = selectSeed(seed)
+	provider.weights = &weightedSelector{random: newRandom(provider.seed)}
+	provider.selector = provider.weights
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// Add adds a new row with a weight of 1 to this provider.
+func (this *WeightedProvider3[T0, T1, T2]) Add(v0 T0, v1 T1, v2 T2) Provider3[T0, T1, T2] {
+	return this.AddWeighted(1, v0, v1, v2)
+}
+
+// AddWeighted adds a new row to this provider, which is drawn in proportion to
+// its `weight`. Rows with a weight of 0 or less are never drawn.
+func (this *WeightedProvider3[T0, T1, T2]) AddWeighted(weight float64, v0 T0, v1 T1, v2 T2) *WeightedProvider3[T0, T1, T2] {
+	this.weights.add(weight)
+	this.selectingProvider3.Add(v0, v1, v2)
+	return this
+}
+
+// ########## 4 ##########
+
+// WeightedProvider4 stores value tuples which are drawn at random, in proportion
+// to the weight of each row.
+type WeightedProvider4[T0 any, T1 any, T2 any, T3 any] struct {
+	selectingProvider4[T0, T1, T2, T3]
+	seeded
+	weights *weightedSelector
+}
+
+// NewWeighted4 generates a new provider which draws its rows at random with the
+// `seed`, in proportion to their weights. A seed of 0 picks a random seed,
+// which is printed.
+func NewWeighted4[T0 any, T1 any, T2 any, T3 any](seed uint64, target func(T0, T1, T2, T3)) *WeightedProvider4[T0, T1, T2, T3] {
+	provider := new(WeightedProvider4[T0, T1, T2, T3])
+	provider.seed = selectSeed(seed)
+	provider.weights = &weightedSelector{random: newRandom(provider.seed)}
+	provider.selector = provider.weights
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// Add adds a new row with a weight of 1 to this provider.
+func (this *WeightedProvider4[T0, T1, T2, T3]) Add(v0 T0, v1 T1, v2 T2, v3 T3) Provider4[T0, T1, T2, T3] {
+	return this.AddWeighted(1, v0, v1, v2, v3)
+}
+
+// AddWeighted adds a new row to this provider, which is drawn in proportion to
+// its `weight`. Rows with a weight of 0 or less are never drawn.
+func (this *WeightedProvider4[T0, T1, T2, T3]) AddWeighted(weight float64, v0 T0, v1 T1, v2 T2, v3 T3) *WeightedProvider4[T0, T1, T2, T3] {
+	this.weights.add(weight)
+	this.selectingProvider4.Add(v0, v1, v2, v3)
+	return this
+}
+
+// ########## 5 ##########
+
+// WeightedProvider5 stores value tuples which are drawn at random, in proportion
+// to the weight of each row.
+type WeightedProvider5[T0 any, T1 any, T2 any, T3 any, T4 any] struct {
+	selectingProvider5[T0, T1, T2, T3, T4]
+	seeded
+	weights *weightedSelector
+}
+
+// NewWeighted5 generates a new provider which draws its rows at random with the
+// `seed`, in proportion to their weights. A seed of 0 picks a random seed,
+// which is printed.
+func NewWeighted5[T0 any, T1 any, T2 any, T3 any, T4 any](seed uint64, target func(T0, T1, T2, T3, T4)) *WeightedProvider5[T0, T1, T2, T3, T4] {
+	provider := new(WeightedProvider5[T0, T1, T2, T3, T4])
+	provider.seed = selectSeed(seed)
+	provider.weights = &weightedSelector{random: newRandom(provider.seed)}
+	provider.selector = provider.weights
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// Add adds a new row with a weight of 1 to this provider.
+func (this *WeightedProvider5[T0, T1, T2, T3, T4]) Add(v0 T0, v1 T1, v2 T2, v3 T3, v4 T4) Provider5[T0, T1, T2, T3, T4] {
+	return this.AddWeighted(1, v0, v1, v2, v3, v4)
+}
+
+// AddWeighted adds a new row to this provider, which is drawn in proportion to
+// its `weight`. Rows with a weight of 0 or less are never drawn.
+func (this *WeightedProvider5[T0, T1, T2, T3, T4]) AddWeighted(weight float64, v0 T0, v1 T1, v2 T2, v3 T3, v4 T4) *WeightedProvider5[T0, T1, T2, T3, T4] {
+	this.weights.add(weight)
+	this.selectingProvider5.Add(v0, v1, v2, v3, v4)
+	return this
+}
+
+// ########## 6 ##########
+
+// WeightedProvider6 stores value tuples which are drawn at random, in proportion
+// to the weight of each row.
+type WeightedProvider6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any] struct {
+	selectingProvider6[T0, T1, T2, T3, T4, T5]
+	seeded
+	weights *weightedSelector
+}
+
+// NewWeighted6 generates a new provider which draws its rows at random with the
+// `seed`, in proportion to their weights. A seed of 0 picks a random seed,
+// which is printed.
+func NewWeighted6[T0 any, T1 any, T2 any, T3 any, T4 any, T5 any](seed uint64, target func(T0, T1, T2, T3, T4, T5)) *WeightedProvider6[T0, T1, T2, T3, T4, T5] {
+	provider := new(WeightedProvider6[T0, T1, T2, T3, T4, T5])
+	provider.seed = selectSeed(seed)
+	provider.weights = &weightedSelector{random: newRandom(provider.seed)}
+	provider.selector = provider.weights
+	provider.benchmark = provider.WrapBenchmarkFunc(target)
+	return provider
+}
+
+// Add adds a new row with a weight of 1 to this provider.
+func (this *WeightedProvider6[T0, T1, T2, T3, T4, T5]) Add(v0 T0, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5) Provider6[T0, T1, T2, T3, T4, T5] {
+	return this.AddWeighted(1, v0, v1, v2, v3, v4, v5)
+}
+
+// AddWeighted adds a new row to this provider, which is drawn in proportion to
+// its `weight`. Rows with a weight of 0 or less are never drawn.
+func (this *WeightedProvider6[T0, T1, T2, T3, T4, T5]) AddWeighted(weight float64, v0 T0, v1 T1, v2 T2, v3 T3, v4 T4, v5 T5) *WeightedProvider6[T0, T1, T2, T3, T4, T5] {
+	this.weights.add(weight)
+	this.selectingProvider6.Add(v0, v1, v2, v3, v4, v5)
+	return this
+}
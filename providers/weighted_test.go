@@ -0,0 +1,40 @@
+package providers
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWeightedSelector_DrawsInProportionToWeights(t *testing.T) {
+	selector := &weightedSelector{random: newRandom(42)}
+	for _, weight := range []float64{80, 0, 15, 5} {
+		selector.add(weight)
+	}
+
+	counts := make([]int, 4)
+	for range 100_000 {
+		counts[selector.next(4)]++
+	}
+
+	for index, expected := range []float64{0.80, 0, 0.15, 0.05} {
+		actual := float64(counts[index]) / 100_000
+		if math.Abs(actual-expected) > 0.01 {
+			t.Errorf("expected row %d to be drawn %0.2f of the time, got %0.3f", index, expected, actual)
+		}
+	}
+}
+
+func TestWeightedProvider_AddHasWeightOne(t *testing.T) {
+	drawn := make(map[string]int)
+	provider := NewWeighted1(1, func(value string) { drawn[value]++ })
+	provider.AddWeighted(3, "hot").Add("cold")
+
+	benchmark := provider.BenchmarkFunc()
+	for range 40_000 {
+		benchmark()
+	}
+
+	if ratio := float64(drawn["hot"]) / float64(drawn["cold"]); math.Abs(ratio-3) > 0.2 {
+		t.Errorf("expected \"hot\" to be drawn 3 times as often as \"cold\", got %0.2f", ratio)
+	}
+}
@@ -0,0 +1,180 @@
+package benchy
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/smarty/benchy/internal/rendering"
+	"github.com/smarty/benchy/stats"
+)
+
+// WriteHTMLReport writes a single, self-contained HTML page for one or more
+// runs of benchmarks, which can be attached to a pull request. The page shows
+// the environment of the run, the report card of every run, a box plot of all
+// benchmarks, and the histogram and samples over time of every benchmark.
+// Charts are inline SVG, so the page does not load anything else.
+//
+// Parameters:
+//   - writer receives the page. It is not closed.
+//   - runs are the results of each run, for example the results returned by
+//     [Benchy.Run] and earlier results read with [ReadResultsFromFile].
+//
+// Returns:
+//   - err is `nil` on a successful operation. Otherwise, err contains the
+//     error that was returned from writing to `writer`.
+func WriteHTMLReport(writer io.Writer, runs ...*stats.BenchmarkResults) (err error) {
+	collections := make([][]*stats.BenchmarkResult, 0, len(runs))
+	for _, run := range runs {
+		collections = append(collections, run.Collection)
+	}
+
+	return rendering.HTMLReport(writer, rendering.CurrentMetadata(), collections...)
+}
+
+// WriteMarkdownReport writes the results as Markdown, which can be posted as a
+// pull request comment. It contains the report card as a table and a
+// collapsible section for every benchmark with its histogram.
+//
+// Parameters:
+//   - writer receives the Markdown. It is not closed.
+//   - results are the results to write, for example the results returned by
+//     [Benchy.Run].
+//
+// Returns:
+//   - err is `nil` on a successful operation. Otherwise, err contains the
+//     error that was returned from writing to `writer`.
+func WriteMarkdownReport(writer io.Writer, results *stats.BenchmarkResults) (err error) {
+	sampleCount := 0
+	for iResult, result := range results.Collection {
+		if iResult == 0 || len(result.Samples) < sampleCount {
+			sampleCount = len(result.Samples)
+		}
+	}
+
+	lines := rendering.MarkdownReport(
+		results.Collection,
+		sampleCount,
+		rendering.CurrentMetadata(),
+		rendering.ReportCardSettings{},
+		extraColumns(rendering.RenderMemoryFunc, rendering.RenderResourceUsageFunc)...)
+	return writeLines(writer, lines)
+}
+
+// extraColumns are the columns of the report card after the statistics, in
+// the same order for every report. `memory` and `usage` are `nil` when they
+// are not shown.
+func extraColumns(memory rendering.ExtraRenderingFunc, usage rendering.ExtraRenderingFunc) []rendering.ExtraRenderingFunc {
+	return []rendering.ExtraRenderingFunc{
+		rendering.RenderThroughputFunc,
+		memory,
+		usage,
+		rendering.RenderMetricsFunc,
+	}
+}
+
+// WriteMarkdownComparison writes a Markdown table which compares the average
+// of every benchmark in `target` to the benchmark with the same name in `base`,
+// with the change in percent and whether the change is significant.
+//
+// Parameters:
+//   - writer receives the Markdown. It is not closed.
+//   - base are the results to compare to, for example the results of the main
+//     branch read with [ReadResultsFromFile].
+//   - target are the results to compare, for example the results of a pull
+//     request.
+//
+// Returns:
+//   - err is `nil` on a successful operation. Otherwise, err contains the
+//     error that was returned from writing to `writer`.
+func WriteMarkdownComparison(writer io.Writer, base *stats.BenchmarkResults, target *stats.BenchmarkResults) (err error) {
+	return writeLines(writer, rendering.MarkdownComparison(base.Collection, target.Collection))
+}
+
+// WriteJUnitReport writes every assertion made on the results as a JUnit XML
+// test case, so that CI systems show performance assertions with the other
+// test results. Failed assertions carry their error, and every test case lists
+// the measured values of the benchmarks it compared. Write the report after
+// the last call to AssertThat.
+//
+// Parameters:
+//   - writer receives the XML. It is not closed.
+//   - results are the results that assertions were made on, for example the
+//     results returned by [Benchy.Run] of several benchmarks.
+//
+// Returns:
+//   - err is `nil` on a successful operation. Otherwise, err contains the
+//     error that was returned from writing to `writer`.
+func WriteJUnitReport(writer io.Writer, results ...*stats.BenchmarkResults) (err error) {
+	assertions := make([]stats.Assertion, 0)
+	for _, result := range results {
+		assertions = append(assertions, result.Assertions...)
+	}
+
+	return rendering.JUnitReport(writer, assertions)
+}
+
+// WritePrometheusMetrics writes the results in the Prometheus text exposition
+// format. The duration of every benchmark is a summary in seconds with its
+// quartiles, and allocations, memory growth, throughput and custom metrics are
+// gauges. Every sample is labelled with the benchmark name, the Go version,
+// the operating system and the architecture.
+//
+// Parameters:
+//   - writer receives the metrics. It is not closed.
+//   - results are the results to write, for example the results returned by
+//     [Benchy.Run].
+//
+// Returns:
+//   - err is `nil` on a successful operation. Otherwise, err contains the
+//     error that was returned from writing to `writer`.
+func WritePrometheusMetrics(writer io.Writer, results *stats.BenchmarkResults) (err error) {
+	return writeLines(writer, rendering.PrometheusMetrics(results.Collection, rendering.CurrentMetadata()))
+}
+
+// WritePrometheusFile writes the results like [WritePrometheusMetrics] to the
+// file at `path`, such as a "*.prom" file in the directory of the textfile
+// collector of node_exporter. The file is written next to `path` first and
+// then renamed, so that the collector never reads a partial file.
+//
+// Parameters:
+//   - path is the file to write. It is replaced if it exists.
+//   - results are the results to write.
+//
+// Returns:
+//   - err is `nil` on a successful operation. Otherwise, err contains the
+//     error that occurred while writing or renaming the file.
+func WritePrometheusFile(path string, results *stats.BenchmarkResults) (err error) {
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = os.Remove(file.Name())
+		}
+	}()
+
+	if err = WritePrometheusMetrics(file, results); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	if err = file.Close(); err != nil {
+		return err
+	}
+
+	// temporary files are only readable by the owner
+	if err = os.Chmod(file.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func writeLines(writer io.Writer, lines []string) (err error) {
+	_, err = io.WriteString(writer, strings.Join(lines, "\n")+"\n")
+	return err
+}
@@ -0,0 +1,33 @@
+package benchy
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/smarty/benchy/stats"
+)
+
+func TestWriteMarkdownReport_IncludesMemoryAndUsageColumns(t *testing.T) {
+	// the memory and resource usage columns are only shown with full statistics
+	results := &stats.BenchmarkResults{Collection: []*stats.BenchmarkResult{{
+		Name:         "fib",
+		Samples:      slices.Repeat([]stats.Duration{2_000}, stats.MinFullCalculation),
+		Average:      2_000,
+		Median:       2_000,
+		Allocations:  1.5,
+		MemoryGrowth: 0.25,
+	}}}
+	buffer := &bytes.Buffer{}
+
+	if err := WriteMarkdownReport(buffer, results); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, column := range []string{"ALLOCATIONS", "MEMORY GROWTH", "USER TIME", "MAJOR FAULTS"} {
+		if !strings.Contains(buffer.String(), column) {
+			t.Errorf("expected the report card to contain %q:\n%s", column, buffer.String())
+		}
+	}
+}
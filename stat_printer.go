@@ -1,41 +1,212 @@
 package benchy
 
 import (
-	"fmt"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
 
+	"github.com/smarty/benchy/internal/profiling"
 	"github.com/smarty/benchy/internal/rendering"
+	"github.com/smarty/benchy/options"
 	"github.com/smarty/benchy/stats"
 )
 
 type statPrinter interface {
-	printHistogram(result *stats.BenchmarkResult, sampleCount int)
-	printReportCard(results []*stats.BenchmarkResult, sampleCount int, renderingFunc rendering.ExtraRenderingFunc)
+	printHistogram(result *stats.BenchmarkResult, sampleCount int, style options.HistogramStyle)
+	printHotFunctions(result *stats.BenchmarkResult)
+	printDistributionPlot(results []*stats.BenchmarkResult, sampleCount int, style options.PlotStyle)
+	printReportCard(results []*stats.BenchmarkResult, sampleCount int, settings rendering.ReportCardSettings, renderingFuncs []rendering.ExtraRenderingFunc)
+	printProfileDiff(baseName string, targetName string, deltas []profiling.FunctionDelta)
 }
 
-type activePrinter struct{}
+func newPrinter(tb testing.TB, writer io.Writer, format options.OutputFormat, theme options.Theme, width int) statPrinter {
+	switch format {
+	case options.JSONFormat:
+		return &jsonPrinter{tb: tb, writer: writer}
 
-type nullPrinter struct{}
+	case options.HTMLFormat:
+		return &htmlPrinter{tb: tb, writer: writer}
 
-func (this *activePrinter) printHistogram(result *stats.BenchmarkResult, sampleCount int) {
+	case options.MarkdownFormat:
+		return &markdownPrinter{writer: newActivePrinter(writer, options.NoColorTheme(), width)}
+
+	default:
+		return newActivePrinter(writer, theme, width)
+	}
+}
+
+// ----- Active ------
+
+type activePrinter struct {
+	writer io.Writer
+	theme  options.Theme
+	width  int
+}
+
+func newActivePrinter(writer io.Writer, theme options.Theme, width int) *activePrinter {
+	return &activePrinter{writer: writer, theme: theme, width: width}
+}
+
+func (this *activePrinter) printHistogram(result *stats.BenchmarkResult, sampleCount int, style options.HistogramStyle) {
 	if sampleCount >= stats.MinFullCalculation {
-		fmt.Println()
-		printLines(rendering.Histogram(*result))
+		this.printLines("")
+		this.printLines(rendering.Histogram(*result, style, this.width, this.theme)...)
 	}
 }
 
-func (this *activePrinter) printReportCard(results []*stats.BenchmarkResult, sampleCount int, renderingFunc rendering.ExtraRenderingFunc) {
-	fmt.Println()
-	printLines(rendering.ReportCard(results, sampleCount, renderingFunc))
-	fmt.Println()
+func (this *activePrinter) printHotFunctions(result *stats.BenchmarkResult) {
+	if len(result.HotFunctions) > 0 {
+		this.printLines("")
+		this.printLines(rendering.HotFunctions(*result, this.theme)...)
+	}
 }
 
-func (this *nullPrinter) printHistogram(result *stats.BenchmarkResult, sampleCount int) {}
+func (this *activePrinter) printDistributionPlot(results []*stats.BenchmarkResult, sampleCount int, style options.PlotStyle) {
+	if sampleCount >= stats.MinFullCalculation {
+		this.printLines("")
+		this.printLines(rendering.DistributionPlot(results, style, this.width, this.theme)...)
+	}
+}
 
-func (this *nullPrinter) printReportCard(results []*stats.BenchmarkResult, sampleCount int, renderingFunc rendering.ExtraRenderingFunc) {
+func (this *activePrinter) printReportCard(results []*stats.BenchmarkResult, sampleCount int, settings rendering.ReportCardSettings, renderingFuncs []rendering.ExtraRenderingFunc) {
+	this.printLines("")
+	settings.Theme = this.theme
+	this.printLines(rendering.ReportCard(results, sampleCount, settings, renderingFuncs...)...)
+	this.printLines("")
 }
 
-func printLines(lines []string) {
+func (this *activePrinter) printProfileDiff(baseName string, targetName string, deltas []profiling.FunctionDelta) {
+	if len(deltas) > 0 {
+		this.printLines(rendering.ProfileDiff(baseName, targetName, deltas, this.theme)...)
+		this.printLines("")
+	}
+}
+
+func (this *activePrinter) printLines(lines ...string) {
+	// the lines are written at once, so that a writer which logs keeps tables
+	// in a single entry
+	sb := strings.Builder{}
 	for _, line := range lines {
-		fmt.Println(line)
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+
+	_, _ = io.WriteString(this.writer, sb.String())
+}
+
+// logWriter writes through testing.TB.Log, which attributes the output to the
+// running benchmark.
+type logWriter struct {
+	tb testing.TB
+}
+
+func (this *logWriter) Write(p []byte) (n int, err error) {
+	this.tb.Log(strings.TrimSuffix(string(p), "\n"))
+	return len(p), nil
+}
+
+// ----- JSON ------
+
+type jsonPrinter struct {
+	tb     testing.TB
+	writer io.Writer
+}
+
+func (this *jsonPrinter) printHistogram(result *stats.BenchmarkResult, sampleCount int, style options.HistogramStyle) {
+}
+
+func (this *jsonPrinter) printHotFunctions(result *stats.BenchmarkResult) {}
+
+func (this *jsonPrinter) printDistributionPlot(results []*stats.BenchmarkResult, sampleCount int, style options.PlotStyle) {
+}
+
+func (this *jsonPrinter) printReportCard(results []*stats.BenchmarkResult, sampleCount int, settings rendering.ReportCardSettings, renderingFuncs []rendering.ExtraRenderingFunc) {
+	encoder := json.NewEncoder(this.writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(results); err != nil {
+		this.tb.Errorf("cannot write the results as json: %v", err)
+	}
+}
+
+func (this *jsonPrinter) printProfileDiff(baseName string, targetName string, deltas []profiling.FunctionDelta) {
+}
+
+// ----- HTML ------
+
+type htmlPrinter struct {
+	tb     testing.TB
+	writer io.Writer
+}
+
+func (this *htmlPrinter) printHistogram(result *stats.BenchmarkResult, sampleCount int, style options.HistogramStyle) {
+}
+
+func (this *htmlPrinter) printHotFunctions(result *stats.BenchmarkResult) {}
+
+func (this *htmlPrinter) printDistributionPlot(results []*stats.BenchmarkResult, sampleCount int, style options.PlotStyle) {
+}
+
+func (this *htmlPrinter) printReportCard(results []*stats.BenchmarkResult, sampleCount int, settings rendering.ReportCardSettings, renderingFuncs []rendering.ExtraRenderingFunc) {
+	if err := rendering.HTMLReport(this.writer, rendering.CurrentMetadata(), results); err != nil {
+		this.tb.Errorf("cannot write the results as html: %v", err)
+	}
+}
+
+func (this *htmlPrinter) printProfileDiff(baseName string, targetName string, deltas []profiling.FunctionDelta) {
+}
+
+// ----- Markdown ------
+
+type markdownPrinter struct {
+	writer *activePrinter
+}
+
+func (this *markdownPrinter) printHistogram(result *stats.BenchmarkResult, sampleCount int, style options.HistogramStyle) {
+}
+
+func (this *markdownPrinter) printHotFunctions(result *stats.BenchmarkResult) {}
+
+func (this *markdownPrinter) printDistributionPlot(results []*stats.BenchmarkResult, sampleCount int, style options.PlotStyle) {
+}
+
+func (this *markdownPrinter) printReportCard(results []*stats.BenchmarkResult, sampleCount int, settings rendering.ReportCardSettings, renderingFuncs []rendering.ExtraRenderingFunc) {
+	this.writer.printLines(rendering.MarkdownReport(results, sampleCount, rendering.CurrentMetadata(), settings, renderingFuncs...)...)
+}
+
+func (this *markdownPrinter) printProfileDiff(baseName string, targetName string, deltas []profiling.FunctionDelta) {
+}
+
+// ----- Multi ------
+
+type multiPrinter []statPrinter
+
+func (this multiPrinter) printHistogram(result *stats.BenchmarkResult, sampleCount int, style options.HistogramStyle) {
+	for _, printer := range this {
+		printer.printHistogram(result, sampleCount, style)
+	}
+}
+
+func (this multiPrinter) printHotFunctions(result *stats.BenchmarkResult) {
+	for _, printer := range this {
+		printer.printHotFunctions(result)
+	}
+}
+
+func (this multiPrinter) printDistributionPlot(results []*stats.BenchmarkResult, sampleCount int, style options.PlotStyle) {
+	for _, printer := range this {
+		printer.printDistributionPlot(results, sampleCount, style)
+	}
+}
+
+func (this multiPrinter) printReportCard(results []*stats.BenchmarkResult, sampleCount int, settings rendering.ReportCardSettings, renderingFuncs []rendering.ExtraRenderingFunc) {
+	for _, printer := range this {
+		printer.printReportCard(results, sampleCount, settings, renderingFuncs)
+	}
+}
+
+func (this multiPrinter) printProfileDiff(baseName string, targetName string, deltas []profiling.FunctionDelta) {
+	for _, printer := range this {
+		printer.printProfileDiff(baseName, targetName, deltas)
 	}
 }
@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Assertion is the outcome of a single call to BenchmarkResults.AssertThat.
+type Assertion struct {
+	// Test is the name of the test or benchmark that made the assertion.
+	Test string
+
+	// Name is the name of the operator, such as "FasterThan" or
+	// "NonAllocating".
+	Name string
+
+	// Left is the name of the benchmark that was asserted on.
+	Left string
+
+	// Right are the names of the benchmarks that Left was compared to.
+	Right []string
+
+	// Passed is true when the operator did not return an error.
+	Passed bool
+
+	// Message is the error of a failed assertion.
+	Message string
+
+	// Values are the measured values of every benchmark in the assertion which
+	// could be found, starting with Left.
+	Values []AssertedValue
+}
+
+// AssertedValue contains the measured values of a benchmark at the time of an
+// assertion.
+type AssertedValue struct {
+	// Benchmark is the name of the benchmark.
+	Benchmark string
+
+	// Average is the average of the benchmark, excluding outliers.
+	Average Duration
+
+	// Median is the median of the benchmark, excluding outliers.
+	Median Duration
+
+	// Allocations is the average number of allocations per operation.
+	Allocations float64
+
+	// Metrics are the custom metrics of the benchmark.
+	Metrics []Metric
+}
+
+func newAssertedValue(result *BenchmarkResult) AssertedValue {
+	return AssertedValue{
+		Benchmark:   result.Name,
+		Average:     result.Average,
+		Median:      result.Median,
+		Allocations: result.Allocations,
+		Metrics:     result.Metrics,
+	}
+}
+
+// operatorName finds the name of the function behind `operator`, without the
+// "Is" prefix of the built-in assertions. Operators built by a factory, such
+// as is.NotHot, are named after the factory.
+func operatorName(operator TestOperator) string {
+	function := runtime.FuncForPC(reflect.ValueOf(operator).Pointer())
+	if function == nil {
+		return "unknown"
+	}
+
+	name := function.Name()
+	name = name[strings.LastIndex(name, "/")+1:]
+	if index := strings.Index(name, ".func"); index > 0 {
+		name = name[:index]
+	}
+
+	name = name[strings.LastIndex(name, ".")+1:]
+	if strings.HasPrefix(name, "Is") && len(name) > 2 {
+		name = name[2:]
+	}
+
+	return name
+}
@@ -0,0 +1,22 @@
+package stats
+
+import "testing"
+
+func TestOperatorName(t *testing.T) {
+	factory := func() TestOperator {
+		return func(left *BenchmarkResult, right ...*BenchmarkResult) error { return nil }
+	}
+
+	// only the exported "Is" prefix of the built-in assertions is removed
+	if actual := operatorName(isNamedForTest); actual != "isNamedForTest" {
+		t.Errorf("expected \"isNamedForTest\", got %q", actual)
+	}
+
+	if actual := operatorName(factory()); actual != "TestOperatorName" {
+		t.Errorf("expected \"TestOperatorName\", got %q", actual)
+	}
+}
+
+func isNamedForTest(left *BenchmarkResult, right ...*BenchmarkResult) error {
+	return nil
+}
@@ -19,7 +19,7 @@ type TestOperator func(left *BenchmarkResult, right ...*BenchmarkResult) error
 // BenchmarkResult contains performance metrics useful for comparisons.
 type BenchmarkResult struct {
 	// Samples is a collection of all aggregated benchmark sample timings,
-	// including Outliers.
+	// including Outliers, in the order they were taken.
 	Samples []Duration
 
 	// Outliers is a collection of all the outliers from Samples.
@@ -34,6 +34,14 @@ type BenchmarkResult struct {
 	// Median is the "middle" sample, excluding Outliers.
 	Median Duration
 
+	// Quartile1 is the first quartile of the Samples, excluding Outliers. A
+	// quarter of the samples are shorter.
+	Quartile1 Duration
+
+	// Quartile3 is the third quartile of the Samples, excluding Outliers. A
+	// quarter of the samples are longer.
+	Quartile3 Duration
+
 	// Max is the longest single sample, excluding Outliers.
 	Max Duration
 
@@ -65,6 +73,55 @@ type BenchmarkResult struct {
 	// MemoryGrowth is the average number of allocations per operation that are
 	// not freed.
 	MemoryGrowth float64
+
+	// UserTime is the average CPU time per operation spent in user mode. Like
+	// the other resource usage statistics, it is measured for the whole process
+	// and is only recorded on Linux.
+	UserTime Duration
+
+	// SystemTime is the average CPU time per operation spent in the kernel.
+	SystemTime Duration
+
+	// VoluntaryContextSwitches is the average number of context switches per
+	// operation where the process gave up the CPU, such as waiting for I/O.
+	VoluntaryContextSwitches float64
+
+	// InvoluntaryContextSwitches is the average number of context switches per
+	// operation where the process was preempted.
+	InvoluntaryContextSwitches float64
+
+	// MinorPageFaults is the average number of page faults per operation which
+	// were served without I/O.
+	MinorPageFaults float64
+
+	// MajorPageFaults is the average number of page faults per operation which
+	// required I/O.
+	MajorPageFaults float64
+
+	// BytesPerOp is the number of bytes processed by each operation, as set
+	// when registering the benchmark. Zero means it was not set.
+	BytesPerOp int64
+
+	// ByteThroughput is the distribution of bytes per second, excluding
+	// Outliers. It is only calculated when BytesPerOp is set.
+	ByteThroughput ThroughputStatistics
+
+	// ItemsPerOp is the number of items processed by each operation, as set
+	// when registering the benchmark. Zero means it was not set.
+	ItemsPerOp int64
+
+	// ItemThroughput is the distribution of items per second, excluding
+	// Outliers. It is only calculated when ItemsPerOp is set.
+	ItemThroughput ThroughputStatistics
+
+	// Metrics are the custom metrics reported by the benchmark function, in the
+	// order they were first reported.
+	Metrics []Metric
+
+	// HotFunctions is every function seen by CPU profiling, ordered by flat CPU
+	// time, highest first. It is only set when the benchmark was registered
+	// with options.PProfCPU, and is empty when the profile has no CPU time.
+	HotFunctions []HotFunction
 }
 
 // WriteTo fulfills the io.WriterTo interface.
@@ -206,11 +263,17 @@ func CalculateFullResultStatistics(result *BenchmarkResult) {
 	result.Min, result.Max = statistics.MinMax(result.Samples)
 	result.Average = statistics.Average(coreSamples)
 	result.Median = statistics.Median(coreSamples)
+	result.Quartile1, result.Quartile3 = statistics.Quartiles(coreSamples)
 	result.StandardError = statistics.StandardError(coreSamples)
 	result.StandardDeviation = statistics.StandardDeviation(coreSamples)
 	result.Histogram = statistics.Histogram(result.Samples)
 	result.Modality = statistics.ModalityFromHistogram(result.Histogram)
 	result.FourSigma = statistics.FourSigma(result.Samples, result.StandardDeviation)
+	result.ByteThroughput = CalculateThroughputStatistics(coreSamples, result.BytesPerOp)
+	result.ItemThroughput = CalculateThroughputStatistics(coreSamples, result.ItemsPerOp)
+	for iMetric := range result.Metrics {
+		CalculateMetricStatistics(&result.Metrics[iMetric])
+	}
 }
 
 // CalculateAverage only calculates the average statistic for this result.
@@ -29,3 +29,14 @@ func TestBenchmarkResult_WriteAndRead(t *testing.T) {
 		t.Errorf("expected: %v, actual: %v", expected, actual)
 	}
 }
+
+func TestCalculateFullResultStatistics_KeepsSampleOrder(t *testing.T) {
+	result := &BenchmarkResult{Samples: []Duration{5, 1, 4, 2, 3, 9, 8, 7, 6, 50}}
+	expected := append([]Duration(nil), result.Samples...)
+
+	CalculateFullResultStatistics(result)
+
+	if !reflect.DeepEqual(expected, result.Samples) {
+		t.Errorf("expected samples in order %v, got %v", expected, result.Samples)
+	}
+}
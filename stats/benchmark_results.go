@@ -14,6 +14,10 @@ type BenchmarkResults struct {
 
 	// Collection is the direct accessor for the collection of BenchmarkResult.
 	Collection []*BenchmarkResult
+
+	// Assertions records every call to AssertThat in the order it was made,
+	// whether it passed or failed.
+	Assertions []Assertion
 }
 
 // NewBenchmarkResults generates a new collection of results that can be
@@ -80,7 +84,8 @@ func (this *BenchmarkResults) ReadFrom(reader io.Reader) (count int64, err error
 	return count, nil
 }
 
-// AssertThat tests a specified condition on one or more benchmarks.
+// AssertThat tests a specified condition on one or more benchmarks. Every
+// assertion is recorded in Assertions.
 func (this *BenchmarkResults) AssertThat(left string, operator TestOperator, right ...string) *BenchmarkResults {
 	var (
 		leftResult   *BenchmarkResult
@@ -99,27 +104,50 @@ func (this *BenchmarkResults) AssertThat(left string, operator TestOperator, rig
 		}
 	}
 
-	exitEarly := false
+	assertion := Assertion{
+		Test:   this.tb.Name(),
+		Name:   operatorName(operator),
+		Left:   left,
+		Right:  right,
+		Passed: true,
+	}
+
+	if leftResult != nil {
+		assertion.Values = append(assertion.Values, newAssertedValue(leftResult))
+	}
+
+	for _, rightResult := range rightResults {
+		if rightResult != nil {
+			assertion.Values = append(assertion.Values, newAssertedValue(rightResult))
+		}
+	}
+
+	errs := make([]string, 0)
 	if leftResult == nil {
-		this.tb.Errorf("assertion error: %v", generateBenchmarkNotFoundError(left))
-		exitEarly = true
+		errs = append(errs, generateBenchmarkNotFoundError(left).Error())
 	}
 
 	for iResult, rightResult := range rightResults {
 		if rightResult == nil {
-			this.tb.Errorf("assertion error: %v", generateBenchmarkNotFoundError(right[iResult]))
-			exitEarly = true
+			errs = append(errs, generateBenchmarkNotFoundError(right[iResult]).Error())
+		}
+	}
+
+	if len(errs) == 0 {
+		if err := operator(leftResult, rightResults...); err != nil {
+			errs = append(errs, err.Error())
 		}
 	}
 
-	if exitEarly {
-		return this
+	for _, err := range errs {
+		this.tb.Errorf("assertion error: %v", err)
 	}
 
-	err := operator(leftResult, rightResults...)
-	if err != nil {
-		this.tb.Errorf("assertion error: %v", err.Error())
+	if len(errs) > 0 {
+		assertion.Passed = false
+		assertion.Message = strings.Join(errs, "\n")
 	}
 
+	this.Assertions = append(this.Assertions, assertion)
 	return this
 }
@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
 )
@@ -99,6 +100,16 @@ func (this *Duration) RenderWithUnit(unit string) string {
 		scaleToUnit(*this, unit))
 }
 
+// MarshalJSON fulfills the json.Marshaler interface. Values which are not a
+// number, such as the deviation of a single sample, are written as null.
+func (this Duration) MarshalJSON() ([]byte, error) {
+	if math.IsNaN(float64(this)) || math.IsInf(float64(this), 0) {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(float64(this))
+}
+
 // SmallestUnit returns the smallest unit in the provided collection.
 func SmallestUnit(units ...string) string {
 	unitsInOrder := []string{nanosecondsUnit, microsecondsUnit, millisecondsUnit, secondsUnit}
@@ -1,6 +1,7 @@
 package stats
 
 import (
+	"math"
 	"testing"
 )
 
@@ -183,3 +184,23 @@ func TestSmallestUnit(t *testing.T) {
 		}
 	}
 }
+
+func TestDuration_MarshalJSON(t *testing.T) {
+	type valueExpected struct {
+		Value    Duration
+		Expected string
+	}
+
+	tests := []valueExpected{
+		{Value: Duration(1.5), Expected: "1.5"},
+		{Value: Duration(math.NaN()), Expected: "null"},
+		{Value: Duration(math.Inf(1)), Expected: "null"},
+	}
+
+	for iTest, test := range tests {
+		actual, err := test.Value.MarshalJSON()
+		if err != nil || string(actual) != test.Expected {
+			t.Errorf("test %d failed: expected %s but got %s (%v)", iTest, test.Expected, actual, err)
+		}
+	}
+}
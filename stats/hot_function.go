@@ -0,0 +1,40 @@
+package stats
+
+// HotFunction is the share of CPU time spent in a single function, as recorded
+// by CPU profiling over all samples of a benchmark.
+type HotFunction struct {
+	// Name is the fully qualified function name, as pprof displays it (for
+	// example "github.com/smarty/benchy/stats.CalculateAverage").
+	Name string
+
+	// Flat is the total CPU time spent in the function itself.
+	Flat Duration
+
+	// FlatPercent is Flat as a percentage of the total profiled CPU time.
+	FlatPercent float64
+
+	// Cumulative is the total CPU time spent in the function and everything
+	// it called.
+	Cumulative Duration
+
+	// CumulativePercent is Cumulative as a percentage of the total profiled
+	// CPU time.
+	CumulativePercent float64
+}
+
+// FindHotFunction looks up a function by its fully qualified name in the hot
+// functions of this result.
+//
+// Returns:
+//   - rank is the zero-based position of the function when ordered by flat
+//     CPU time, highest first. It is -1 when the function was not found.
+//   - function is the hot function that was found.
+func (this *BenchmarkResult) FindHotFunction(name string) (rank int, function HotFunction) {
+	for iFunction, hotFunction := range this.HotFunctions {
+		if hotFunction.Name == name {
+			return iFunction, hotFunction
+		}
+	}
+
+	return -1, HotFunction{}
+}
@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"slices"
+
+	"github.com/smarty/benchy/internal/statistics"
+)
+
+// Metric is a custom, named measurement reported by a benchmark function, such
+// as a cache hit ratio or the number of retries.
+type Metric struct {
+	// Name is the provided name for the metric.
+	Name string
+
+	// Unit is the provided unit of the metric.
+	Unit string
+
+	// Samples is the average value per operation of every sample.
+	Samples []float64
+
+	// Average is the simple average of the Samples.
+	Average float64
+
+	// Median is the "middle" sample.
+	Median float64
+
+	// Max is the largest single sample.
+	Max float64
+
+	// Min is the smallest single sample.
+	Min float64
+
+	// StandardDeviation is the standard deviation from Samples.
+	StandardDeviation float64
+
+	// StandardError is the standard error from Samples.
+	StandardError float64
+}
+
+// FindMetric looks up a custom metric by name in this result.
+func (this *BenchmarkResult) FindMetric(name string) (metric Metric, found bool) {
+	for _, metric = range this.Metrics {
+		if metric.Name == name {
+			return metric, true
+		}
+	}
+
+	return Metric{}, false
+}
+
+// CalculateMetricStatistics calculates all the statistics for the metric.
+func CalculateMetricStatistics(metric *Metric) {
+	if len(metric.Samples) == 0 {
+		return
+	}
+
+	// the statistics functions sort in place, but the samples stay in the order
+	// they were taken
+	samples := slices.Clone(metric.Samples)
+	metric.Min, metric.Max = statistics.MinMax(samples)
+	metric.Average = statistics.Average(samples)
+	metric.Median = statistics.Median(samples)
+	metric.StandardDeviation = 0
+	metric.StandardError = 0
+	if len(samples) > 1 {
+		metric.StandardDeviation = statistics.StandardDeviation(samples)
+		metric.StandardError = statistics.StandardError(samples)
+	}
+}
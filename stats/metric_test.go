@@ -0,0 +1,36 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCalculateMetricStatistics(t *testing.T) {
+	metric := &Metric{Name: "retries", Samples: []float64{3, 1, 2}}
+
+	CalculateMetricStatistics(metric)
+
+	expected := &Metric{
+		Name:              "retries",
+		Samples:           []float64{3, 1, 2},
+		Average:           2,
+		Median:            2,
+		Max:               3,
+		Min:               1,
+		StandardDeviation: 1,
+		StandardError:     metric.StandardError,
+	}
+	if !reflect.DeepEqual(expected, metric) {
+		t.Errorf("expected: %+v, actual: %+v", expected, metric)
+	}
+}
+
+func TestCalculateMetricStatistics_SingleSample(t *testing.T) {
+	metric := &Metric{Name: "retries", Samples: []float64{4}}
+
+	CalculateMetricStatistics(metric)
+
+	if metric.Average != 4 || metric.StandardDeviation != 0 {
+		t.Errorf("expected an average of 4 without deviation, got %+v", metric)
+	}
+}
@@ -0,0 +1,151 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/smarty/benchy/internal/statistics"
+)
+
+const (
+	// BytesSuffix is rendered after byte throughputs, as in "MB/s".
+	BytesSuffix = "B/s"
+
+	// ItemsSuffix is rendered after item throughputs, as in "Mitems/s".
+	ItemsSuffix = "items/s"
+
+	gigaUnit = "G"
+	megaUnit = "M"
+	kiloUnit = "K"
+	oneUnit  = ""
+
+	giga = Throughput(1_000_000_000)
+	mega = Throughput(1_000_000)
+	kilo = Throughput(1_000)
+)
+
+// Throughput is an amount per second, such as bytes per second.
+type Throughput float64
+
+// ThroughputStatistics describes the distribution of throughput over all
+// samples, excluding outliers.
+type ThroughputStatistics struct {
+	// Average is the simple average throughput.
+	Average Throughput
+
+	// Median is the "middle" throughput.
+	Median Throughput
+
+	// Max is the highest throughput of a single sample.
+	Max Throughput
+
+	// Min is the lowest throughput of a single sample.
+	Min Throughput
+
+	// StandardDeviation is the standard deviation of the throughput.
+	StandardDeviation Throughput
+}
+
+// Unit returns the unit prefix that this throughput will be rendered in.
+//
+// Returns:
+//   - unit will appear as one of the following strings: "G", "M", "K", "".
+func (this *Throughput) Unit() (unit string) {
+	switch {
+	case *this >= giga:
+		return gigaUnit
+
+	case *this >= mega:
+		return megaUnit
+
+	case *this >= kilo:
+		return kiloUnit
+
+	default:
+		return oneUnit
+	}
+}
+
+// Render renders this throughput scaled to the most fitting unit, followed by
+// `suffix` (See [BytesSuffix] and [ItemsSuffix]).
+func (this *Throughput) Render(suffix string) string {
+	return this.RenderWithUnit(this.Unit(), suffix)
+}
+
+// RenderWithUnit renders this throughput scaled to the provided unit, followed
+// by `suffix`.
+func (this *Throughput) RenderWithUnit(unit string, suffix string) string {
+	return fmt.Sprintf(
+		fmt.Sprintf("%s0.%df %s%s", "%", renderedDecimalPlaces, unit, suffix),
+		scaleThroughputToUnit(*this, unit))
+}
+
+// RenderLengthAsUnit returns the length of the string when this throughput is
+// printed using the provided unit and suffix.
+func (this *Throughput) RenderLengthAsUnit(unit string, suffix string) int {
+	return stringLength(this.RenderWithUnit(unit, suffix))
+}
+
+// SmallestThroughputUnit returns the smallest unit in the provided collection.
+func SmallestThroughputUnit(units ...string) string {
+	unitsInOrder := []string{oneUnit, kiloUnit, megaUnit, gigaUnit}
+	index := len(unitsInOrder) - 1
+	for _, unit := range units {
+		for orderedIndex, orderedUnit := range unitsInOrder {
+			if orderedUnit == unit {
+				index = min(orderedIndex, index)
+			}
+		}
+	}
+
+	return unitsInOrder[index]
+}
+
+// CalculateThroughputStatistics calculates the throughput of every sample,
+// given the amount processed per operation.
+func CalculateThroughputStatistics(samples []Duration, perOperation int64) ThroughputStatistics {
+	if perOperation <= 0 || len(samples) == 0 {
+		return ThroughputStatistics{}
+	}
+
+	throughputs := make([]Throughput, 0, len(samples))
+	for _, sample := range samples {
+		if sample <= 0 {
+			continue
+		}
+
+		throughputs = append(throughputs, Throughput(float64(perOperation)*float64(seconds)/float64(sample)))
+	}
+
+	if len(throughputs) == 0 {
+		return ThroughputStatistics{}
+	}
+
+	result := ThroughputStatistics{}
+	result.Min, result.Max = statistics.MinMax(throughputs)
+	result.Average = statistics.Average(throughputs)
+	result.Median = statistics.Median(throughputs)
+	if len(throughputs) > 1 {
+		result.StandardDeviation = statistics.StandardDeviation(throughputs)
+	}
+
+	return result
+}
+
+func scaleThroughputToUnit(throughput Throughput, unit string) Throughput {
+	var divisor Throughput
+	switch unit {
+	case gigaUnit:
+		divisor = giga
+
+	case megaUnit:
+		divisor = mega
+
+	case kiloUnit:
+		divisor = kilo
+
+	default:
+		divisor = Throughput(1)
+	}
+
+	return throughput / divisor
+}
@@ -0,0 +1,36 @@
+package stats
+
+import (
+	"testing"
+)
+
+func TestThroughput_Render(t *testing.T) {
+	type valueExpected struct {
+		Value    Throughput
+		Expected string
+	}
+
+	tests := []valueExpected{
+		{Value: Throughput(12), Expected: "12.000 B/s"},
+		{Value: Throughput(1_500), Expected: "1.500 KB/s"},
+		{Value: Throughput(2_000_000), Expected: "2.000 MB/s"},
+		{Value: Throughput(3_250_000_000), Expected: "3.250 GB/s"},
+	}
+
+	for iTest, test := range tests {
+		actual := test.Value.Render(BytesSuffix)
+		if actual != test.Expected {
+			t.Errorf("test %d failed: expected %s but got %s", iTest, test.Expected, actual)
+		}
+	}
+}
+
+func TestCalculateThroughputStatistics(t *testing.T) {
+	samples := []Duration{1_000, 2_000, 4_000}
+
+	actual := CalculateThroughputStatistics(samples, 1_000)
+
+	if actual.Max != 1_000_000_000 || actual.Min != 250_000_000 || actual.Median != 500_000_000 {
+		t.Errorf("expected throughput from 250MB/s to 1GB/s with a median of 500MB/s, got %+v", actual)
+	}
+}